@@ -0,0 +1,204 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"partage-projets/config"
+	"partage-projets/migrations"
+	"partage-projets/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProjectLike struct {
+	UserID    uint `json:"user_id"`
+	ProjectID uint `json:"project_id"`
+}
+
+func (ProjectLike) TableName() string {
+	return "project_likes"
+}
+
+type ProjectCollaborator struct {
+	UserID    uint `json:"user_id"`
+	ProjectID uint `json:"project_id"`
+}
+
+func (ProjectCollaborator) TableName() string {
+	return "project_collaborators"
+}
+
+// Dump is the content of the database, as written by export and read by import.
+type Dump struct {
+	SchemaVersion        string                   `json:"schema_version"`
+	ExportedAt           time.Time                `json:"exported_at"`
+	Users                []models.User            `json:"users"`
+	Projects             []models.Project         `json:"projects"`
+	Comments             []models.Comment         `json:"comments"`
+	ProjectImages        []models.ProjectImage    `json:"project_images"`
+	ProjectRevisions     []models.ProjectRevision `json:"project_revisions"`
+	ProjectLikes         []ProjectLike            `json:"project_likes"`
+	ProjectCollaborators []ProjectCollaborator    `json:"project_collaborators"`
+	Uploads              []models.Upload          `json:"uploads"`
+	RoleQuotas           []models.RoleQuota       `json:"role_quotas"`
+}
+
+func Export(db *gorm.DB, writer io.Writer) error {
+	version, err := migrations.Current(db)
+	if err != nil {
+		return err
+	}
+
+	dump := Dump{SchemaVersion: version, ExportedAt: time.Now()}
+
+	// Projects in the trash are exported too, so that they can still be restored after an import.
+	// Deleted users and comments can't be restored, and are left out.
+	if err := db.Unscoped().Find(&dump.Projects).Error; err != nil {
+		return err
+	}
+
+	tables := []interface{}{
+		&dump.Users,
+		&dump.Comments,
+		&dump.ProjectImages,
+		&dump.ProjectRevisions,
+		&dump.ProjectLikes,
+		&dump.ProjectCollaborators,
+		&dump.Uploads,
+		&dump.RoleQuotas,
+	}
+
+	for _, table := range tables {
+		if err := db.Find(table).Error; err != nil {
+			return err
+		}
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(dump)
+}
+
+// Import loads a dump into an empty database having the same schema version, keeping the original IDs.
+func Import(db *gorm.DB, reader io.Reader) error {
+	var dump Dump
+
+	if err := json.NewDecoder(reader).Decode(&dump); err != nil {
+		return err
+	}
+
+	version, err := migrations.Current(db)
+	if err != nil {
+		return err
+	}
+
+	if dump.SchemaVersion != version {
+		return fmt.Errorf("export has schema version %s but database has %s", dump.SchemaVersion, version)
+	}
+
+	var users, projects int64
+	db.Unscoped().Model(&models.User{}).Count(&users)
+	db.Unscoped().Model(&models.Project{}).Count(&projects)
+
+	if users > 0 || projects > 0 {
+		return errors.New("database is not empty, refusing to import")
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		tables := []struct {
+			name string
+			rows interface{}
+			size int
+		}{
+			{"users", &dump.Users, len(dump.Users)},
+			{"projects", &dump.Projects, len(dump.Projects)},
+			{"comments", &dump.Comments, len(dump.Comments)},
+			{"project_images", &dump.ProjectImages, len(dump.ProjectImages)},
+			{"project_revisions", &dump.ProjectRevisions, len(dump.ProjectRevisions)},
+			{"project_likes", &dump.ProjectLikes, len(dump.ProjectLikes)},
+			{"project_collaborators", &dump.ProjectCollaborators, len(dump.ProjectCollaborators)},
+			{"uploads", &dump.Uploads, len(dump.Uploads)},
+			{"role_quota", &dump.RoleQuotas, len(dump.RoleQuotas)},
+		}
+
+		for _, table := range tables {
+			if table.size == 0 {
+				continue
+			}
+
+			if err := tx.Omit(clause.Associations).CreateInBatches(table.rows, 100).Error; err != nil {
+				return fmt.Errorf("%s: %w", table.name, err)
+			}
+		}
+
+		if tx.Dialector.Name() != "postgres" {
+			return nil
+		}
+
+		// Rows were inserted with their IDs, so the sequences must be moved past them.
+		for _, table := range []string{"users", "projects", "comments", "project_images", "project_revisions", "uploads"} {
+			query := fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%[1]s', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM %[1]s", table)
+
+			if err := tx.Exec(query).Error; err != nil {
+				return fmt.Errorf("%s: %w", table, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func export(configuration *config.Config, arguments []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	output := flags.String("output", "", "File to write the export to (standard output by default)")
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return Export(config.DB, os.Stdout)
+	}
+
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return Export(config.DB, file)
+}
+
+func importData(configuration *config.Config, arguments []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	input := flags.String("input", "", "File to read the export from")
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	if *input == "" {
+		return errors.New("usage: import -input <file>")
+	}
+
+	file, err := os.Open(*input)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := Import(config.DB, file); err != nil {
+		return err
+	}
+
+	fmt.Println("Import completed.")
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"partage-projets/config"
+	"partage-projets/logging"
+	"sort"
+)
+
+type command struct {
+	usage       string
+	description string
+	run         func(configuration *config.Config, arguments []string) error
+}
+
+var commands = map[string]command{
+	"serve": {
+		usage:       "serve",
+		description: "Start the HTTP server (default command)",
+		run:         serve,
+	},
+	"migrate": {
+		usage:       "migrate up | down [steps] | status",
+		description: "Apply, roll back or list the database migrations",
+		run:         migrate,
+	},
+	"seed": {
+		usage:       "seed",
+		description: "Fill an empty database with demo data",
+		run:         seed,
+	},
+	"create-user": {
+		usage:       "create-user -email <email> -password <password> [-admin]",
+		description: "Create a user account",
+		run:         createUser,
+	},
+	"promote-admin": {
+		usage:       "promote-admin -email <email>",
+		description: "Give the admin role to an existing user",
+		run:         promoteAdmin,
+	},
+	"export": {
+		usage:       "export [-output <file>]",
+		description: "Export the database content as JSON",
+		run:         export,
+	},
+	"import": {
+		usage:       "import -input <file>",
+		description: "Import a JSON export into an empty database",
+		run:         importData,
+	},
+	"sweep-uploads": {
+		usage:       "sweep-uploads [-dry-run]",
+		description: "Remove uploaded files that are no longer referenced",
+		run:         sweepUploads,
+	},
+	"purge-trash": {
+		usage:       "purge-trash",
+		description: "Remove for good the projects and comments deleted longer ago than the trash retention",
+		run:         purgeTrash,
+	},
+}
+
+// Run executes the subcommand named by the first argument, "serve" when there is none, and returns the exit code.
+func Run(arguments []string) int {
+	name := "serve"
+
+	if len(arguments) > 0 {
+		name, arguments = arguments[0], arguments[1:]
+	}
+
+	command, ok := commands[name]
+	if !ok {
+		printUsage()
+
+		return 2
+	}
+
+	logging.Setup(os.Stderr, slog.LevelInfo)
+
+	configuration, err := load()
+	if err != nil {
+		slog.Error("Invalid configuration.", slog.Any("error", err))
+
+		return 1
+	}
+
+	if err := command.run(configuration, arguments); err != nil {
+		slog.Error("Command failed.", slog.String("command", name), slog.Any("error", err))
+
+		return 1
+	}
+
+	return 0
+}
+
+// load reads the configuration shared by every subcommand, from the YAML file named by CONFIG_FILE if any,
+// and connects to the database.
+func load() (*config.Config, error) {
+	configuration, err := config.Load(os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		return nil, err
+	}
+
+	level, _ := logging.ParseLevel(configuration.LogLevel)
+	logging.Setup(os.Stderr, level)
+
+	config.ConnectDB(configuration.DatabaseDSN)
+
+	return configuration, nil
+}
+
+func printUsage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "Usage:")
+
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-58s %s\n", commands[name].usage, commands[name].description)
+	}
+}
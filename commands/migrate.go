@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"partage-projets/config"
+	"partage-projets/migrations"
+	"strconv"
+	"time"
+)
+
+func migrate(configuration *config.Config, arguments []string) error {
+	if len(arguments) == 0 {
+		return errors.New("usage: migrate up | down [steps] | status")
+	}
+
+	switch arguments[0] {
+	case "up":
+		applied, err := migrations.Up(config.DB)
+		for _, migration := range applied {
+			fmt.Printf("Applied %s_%s\n", migration.Version, migration.Name)
+		}
+
+		return err
+	case "down":
+		steps := 1
+
+		if len(arguments) > 1 {
+			var err error
+
+			steps, err = strconv.Atoi(arguments[1])
+			if err != nil || steps < 1 {
+				return errors.New("invalid number of migrations to roll back: " + arguments[1])
+			}
+		}
+
+		rolledBack, err := migrations.Down(config.DB, steps)
+		for _, migration := range rolledBack {
+			fmt.Printf("Rolled back %s_%s\n", migration.Version, migration.Name)
+		}
+
+		return err
+	case "status":
+		statuses, err := migrations.List(config.DB)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+
+			fmt.Printf("%s_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+
+		return nil
+	default:
+		return errors.New("usage: migrate up | down [steps] | status")
+	}
+}
@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"partage-projets/config"
+	"partage-projets/models"
+	"partage-projets/seeds"
+)
+
+func seed(configuration *config.Config, arguments []string) error {
+	var count int64
+
+	if err := config.DB.Model(&models.User{}).Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return errors.New("database already contains users, refusing to seed it")
+	}
+
+	if err := seeds.Demo(config.DB); err != nil {
+		return err
+	}
+
+	fmt.Printf("Demo data created, users can log in with the password %s.\n", seeds.DemoPassword)
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os/signal"
+	"partage-projets/config"
+	"partage-projets/jobs"
+	"partage-projets/metrics"
+	"partage-projets/middlewares"
+	"partage-projets/migrations"
+	"partage-projets/problem"
+	"partage-projets/repositories"
+	"partage-projets/routes"
+	"partage-projets/tracing"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func serve(configuration *config.Config, arguments []string) error {
+	if configuration.LogLevel != "debug" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), configuration.TracingExporter, configuration.TracingEndpoint, configuration.TracingSampleRatio)
+	if err != nil {
+		return err
+	}
+
+	router := gin.New()
+
+	err = router.SetTrustedProxies(nil)
+	if err != nil {
+		return fmt.Errorf("unable to set trusted proxies: %w", err)
+	}
+
+	router.Use(middlewares.RequestID())
+	router.Use(middlewares.Language(configuration.DefaultLanguage))
+	router.Use(middlewares.Tracing())
+	router.Use(middlewares.Metrics())
+	router.Use(middlewares.Logger())
+	router.Use(middlewares.Recovery())
+	router.Use(config.SecurityMiddleware())
+	router.Use(config.CORSMiddleware(configuration))
+	router.Use(config.RateLimit(configuration.RateLimit))
+
+	routes.Register(router, configuration, config.DB)
+
+	routes.SwaggerRoutes(router)
+	router.NoRoute(problem.NotFound)
+
+	sqlDB, err := config.DB.DB()
+	if err != nil {
+		return fmt.Errorf("unable to access database pool: %w", err)
+	}
+
+	if err := metrics.RegisterDatabase(sqlDB); err != nil {
+		return fmt.Errorf("unable to register database metrics: %w", err)
+	}
+
+	applied, err := migrations.Up(config.DB)
+	if err != nil {
+		return fmt.Errorf("unable to migrate database: %w", err)
+	}
+
+	for _, migration := range applied {
+		slog.Info("Applied migration.", slog.String("version", migration.Version), slog.String("name", migration.Name))
+	}
+
+	// Render sends SIGTERM on every deploy: in-flight requests are drained before exiting.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	workers, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	var sweeperDone <-chan struct{}
+	if configuration.UploadSweepInterval > 0 {
+		sweeperDone = jobs.StartUploadSweeper(workers, repositories.NewUploadRepository(config.DB), configuration.UploadDirectory, configuration.UploadSweepInterval, configuration.UploadSweepGracePeriod)
+	}
+
+	var purgerDone <-chan struct{}
+	if configuration.TrashPurgeInterval > 0 {
+		projects, comments := newTrashServices(configuration, config.DB)
+		purgerDone = jobs.StartTrashPurger(workers, projects, comments, configuration.TrashPurgeInterval)
+	}
+
+	server := NewServer(configuration, router)
+
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return fmt.Errorf("unable to start server: %w", err)
+	}
+
+	slog.Info("Server started.", slog.String("address", "http://localhost:"+strconv.Itoa(configuration.Port)))
+
+	err = Serve(ctx, server, listener, configuration.ShutdownTimeout)
+
+	stopWorkers()
+
+	if sweeperDone != nil {
+		select {
+		case <-sweeperDone:
+		case <-time.After(configuration.ShutdownTimeout):
+			slog.Warn("Upload sweeper did not stop in time.")
+		}
+	}
+
+	if purgerDone != nil {
+		select {
+		case <-purgerDone:
+		case <-time.After(configuration.ShutdownTimeout):
+			slog.Warn("Trash purger did not stop in time.")
+		}
+	}
+
+	err = errors.Join(err, sqlDB.Close())
+
+	// Spans still buffered by the exporter are flushed before exiting.
+	tracingCtx, cancel := context.WithTimeout(context.Background(), configuration.ShutdownTimeout)
+	defer cancel()
+
+	err = errors.Join(err, shutdownTracing(tracingCtx))
+
+	slog.Info("Server stopped.")
+
+	return err
+}
+
+func NewServer(configuration *config.Config, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":" + strconv.Itoa(configuration.Port),
+		Handler:           handler,
+		ReadTimeout:       configuration.ReadTimeout,
+		ReadHeaderTimeout: configuration.ReadHeaderTimeout,
+		WriteTimeout:      configuration.WriteTimeout,
+		IdleTimeout:       configuration.IdleTimeout,
+	}
+}
+
+// Serve handles requests until the context is cancelled, then stops accepting connections
+// and waits for in-flight requests to complete, for at most the shutdown timeout.
+func Serve(ctx context.Context, server *http.Server, listener net.Listener, shutdownTimeout time.Duration) error {
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errs:
+		return fmt.Errorf("unable to serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("Shutting down server.")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("unable to shut down server gracefully: %w", err)
+	}
+
+	if err := <-errs; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
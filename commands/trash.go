@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"partage-projets/config"
+	"partage-projets/jobs"
+	"partage-projets/repositories"
+	"partage-projets/services"
+
+	"gorm.io/gorm"
+)
+
+// newTrashServices builds the services purging the trash, outside of the HTTP routes.
+func newTrashServices(configuration *config.Config, db *gorm.DB) (*services.ProjectService, *services.CommentService) {
+	projectRepository := repositories.NewProjectRepository(db)
+	uploadService := services.NewUploadService(repositories.NewUploadRepository(db), configuration.UploadDirectory)
+
+	projects := services.NewProjectService(projectRepository, uploadService, configuration.TrashRetention)
+	comments := services.NewCommentService(repositories.NewCommentRepository(db), projectRepository, configuration.TrashRetention)
+
+	return projects, comments
+}
+
+func purgeTrash(configuration *config.Config, arguments []string) error {
+	flags := flag.NewFlagSet("purge-trash", flag.ContinueOnError)
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	projects, comments := newTrashServices(configuration, config.DB)
+
+	report, err := jobs.PurgeTrash(context.Background(), projects, comments)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"partage-projets/config"
+	"partage-projets/jobs"
+	"partage-projets/repositories"
+)
+
+func sweepUploads(configuration *config.Config, arguments []string) error {
+	flags := flag.NewFlagSet("sweep-uploads", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "Only report the files that would be removed")
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	report, err := jobs.SweepUploads(context.Background(), repositories.NewUploadRepository(config.DB), configuration.UploadDirectory, *dryRun, configuration.UploadSweepGracePeriod)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
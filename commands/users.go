@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"partage-projets/config"
+	"partage-projets/models"
+	"partage-projets/utils"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func CreateUser(db *gorm.DB, email string, password string, role string) (*models.User, error) {
+	if email == "" {
+		return nil, errors.New("email is required")
+	}
+
+	if err := utils.ValidatePassword(password); err != nil {
+		return nil, err
+	}
+
+	var count int64
+	if err := db.Model(&models.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
+		return nil, err
+	}
+
+	if count > 0 {
+		return nil, errors.New("email already used")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := models.User{Email: email, Password: string(hashedPassword), Role: role}
+
+	if err := db.Create(&user).Error; err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func PromoteAdmin(db *gorm.DB, email string) error {
+	result := db.Model(&models.User{}).Where("email = ?", email).Update("role", models.RoleAdmin)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("no user with email " + email)
+	}
+
+	return nil
+}
+
+func createUser(configuration *config.Config, arguments []string) error {
+	flags := flag.NewFlagSet("create-user", flag.ContinueOnError)
+	email := flags.String("email", "", "Email of the user")
+	password := flags.String("password", "", "Password of the user")
+	admin := flags.Bool("admin", false, "Give the admin role to the user")
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	role := models.RoleUser
+	if *admin {
+		role = models.RoleAdmin
+	}
+
+	user, err := CreateUser(config.DB, *email, *password, role)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("User %s created with ID %d and role %s.\n", user.Email, user.ID, user.Role)
+
+	return nil
+}
+
+func promoteAdmin(configuration *config.Config, arguments []string) error {
+	flags := flag.NewFlagSet("promote-admin", flag.ContinueOnError)
+	email := flags.String("email", "", "Email of the user")
+
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+
+	if err := PromoteAdmin(config.DB, *email); err != nil {
+		return err
+	}
+
+	fmt.Printf("User %s is now an admin.\n", *email)
+
+	return nil
+}
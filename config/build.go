@@ -0,0 +1,46 @@
+package config
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Version and Commit can be set at build time:
+// go build -ldflags "-X partage-projets/config.Version=1.2.0 -X partage-projets/config.Commit=$(git rev-parse HEAD)"
+var (
+	Version = "dev"
+	Commit  = ""
+)
+
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	Modified  bool   `json:"modified"`
+	GoVersion string `json:"go_version"`
+}
+
+// Build returns the build information, falling back to the VCS details recorded by the Go toolchain.
+func Build() BuildInfo {
+	info := BuildInfo{Version: Version, Commit: Commit, GoVersion: runtime.Version()}
+
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	for _, setting := range buildInfo.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = setting.Value
+			}
+		case "vcs.time":
+			info.BuildTime = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+
+	return info
+}
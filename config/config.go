@@ -0,0 +1,298 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"partage-projets/i18n"
+	"partage-projets/logging"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	DatabaseDSN            string        `yaml:"database_dsn"`
+	JWTSecret              string        `yaml:"jwt_secret"`
+	TokenDuration          time.Duration `yaml:"token_duration"`
+	Port                   int           `yaml:"port"`
+	ReadTimeout            time.Duration `yaml:"read_timeout"`
+	ReadHeaderTimeout      time.Duration `yaml:"read_header_timeout"`
+	WriteTimeout           time.Duration `yaml:"write_timeout"`
+	IdleTimeout            time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout        time.Duration `yaml:"shutdown_timeout"`
+	CORSOrigins            []string      `yaml:"cors_origins"`
+	CORSMethods            []string      `yaml:"cors_methods"`
+	CORSHeaders            []string      `yaml:"cors_headers"`
+	CORSCredentials        bool          `yaml:"cors_credentials"`
+	CORSDevMode            bool          `yaml:"cors_dev_mode"`
+	RateLimit              int           `yaml:"rate_limit"`
+	UploadDirectory        string        `yaml:"upload_directory"`
+	UploadSweepInterval    time.Duration `yaml:"upload_sweep_interval"`
+	UploadSweepGracePeriod time.Duration `yaml:"upload_sweep_grace_period"`
+	TrashRetention         time.Duration `yaml:"trash_retention"`
+	TrashPurgeInterval     time.Duration `yaml:"trash_purge_interval"`
+	IfMatchRequired        bool          `yaml:"if_match_required"`
+	LogLevel               string        `yaml:"log_level"`
+	MetricsToken           string        `yaml:"metrics_token"`
+	TracingExporter        string        `yaml:"tracing_exporter"`
+	TracingEndpoint        string        `yaml:"tracing_endpoint"`
+	TracingSampleRatio     float64       `yaml:"tracing_sample_ratio"`
+	DefaultLanguage        string        `yaml:"default_language"`
+}
+
+func Default() *Config {
+	return &Config{
+		TokenDuration:          2 * time.Hour,
+		Port:                   8080,
+		ReadTimeout:            15 * time.Second,
+		ReadHeaderTimeout:      5 * time.Second,
+		WriteTimeout:           30 * time.Second,
+		IdleTimeout:            60 * time.Second,
+		ShutdownTimeout:        25 * time.Second,
+		CORSOrigins:            []string{"http://localhost"},
+		CORSMethods:            []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		CORSHeaders:            []string{"Origin", "Authorization", "Content-Type", "If-Match"},
+		CORSCredentials:        true,
+		RateLimit:              100,
+		UploadDirectory:        "uploads",
+		UploadSweepGracePeriod: time.Hour,
+		TrashRetention:         30 * 24 * time.Hour,
+		LogLevel:               "info",
+		TracingExporter:        "none",
+		TracingSampleRatio:     1,
+		DefaultLanguage:        i18n.English,
+	}
+}
+
+// Load reads the configuration from the defaults, then the YAML file if any, then the environment (and .env file).
+func Load(path string) (*Config, error) {
+	config := Default()
+
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := yaml.Unmarshal(content, config); err != nil {
+			return nil, fmt.Errorf("invalid configuration file %s: %w", path, err)
+		}
+	}
+
+	err := godotenv.Load()
+	if err != nil {
+		// If .env file is not found, it is not necessarily an error.
+		// With Render, environment variables are injected; there is no need for .env file.
+		slog.Info("Unable to find .env file.", slog.Any("error", err))
+	}
+
+	err = errors.Join(
+		stringFromEnv("DATABASE_DSN", &config.DatabaseDSN),
+		stringFromEnv("JWT_SECRET", &config.JWTSecret),
+		durationFromEnv("TOKEN_DURATION", &config.TokenDuration),
+		intFromEnv("PORT", &config.Port),
+		durationFromEnv("READ_TIMEOUT", &config.ReadTimeout),
+		durationFromEnv("READ_HEADER_TIMEOUT", &config.ReadHeaderTimeout),
+		durationFromEnv("WRITE_TIMEOUT", &config.WriteTimeout),
+		durationFromEnv("IDLE_TIMEOUT", &config.IdleTimeout),
+		durationFromEnv("SHUTDOWN_TIMEOUT", &config.ShutdownTimeout),
+		listFromEnv("CORS_ORIGINS", &config.CORSOrigins),
+		listFromEnv("CORS_METHODS", &config.CORSMethods),
+		listFromEnv("CORS_HEADERS", &config.CORSHeaders),
+		boolFromEnv("CORS_CREDENTIALS", &config.CORSCredentials),
+		boolFromEnv("CORS_DEV_MODE", &config.CORSDevMode),
+		intFromEnv("RATE_LIMIT", &config.RateLimit),
+		stringFromEnv("UPLOAD_DIRECTORY", &config.UploadDirectory),
+		durationFromEnv("UPLOAD_SWEEP_INTERVAL", &config.UploadSweepInterval),
+		durationFromEnv("UPLOAD_SWEEP_GRACE_PERIOD", &config.UploadSweepGracePeriod),
+		durationFromEnv("TRASH_RETENTION", &config.TrashRetention),
+		durationFromEnv("TRASH_PURGE_INTERVAL", &config.TrashPurgeInterval),
+		boolFromEnv("IF_MATCH_REQUIRED", &config.IfMatchRequired),
+		stringFromEnv("LOG_LEVEL", &config.LogLevel),
+		stringFromEnv("METRICS_TOKEN", &config.MetricsToken),
+		stringFromEnv("TRACING_EXPORTER", &config.TracingExporter),
+		stringFromEnv("TRACING_ENDPOINT", &config.TracingEndpoint),
+		floatFromEnv("TRACING_SAMPLE_RATIO", &config.TracingSampleRatio),
+		stringFromEnv("DEFAULT_LANGUAGE", &config.DefaultLanguage),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (config *Config) Validate() error {
+	var errs []error
+
+	if config.DatabaseDSN == "" {
+		errs = append(errs, errors.New("DATABASE_DSN is required"))
+	}
+
+	if config.JWTSecret == "" {
+		errs = append(errs, errors.New("JWT_SECRET is required"))
+	}
+
+	if config.TokenDuration <= 0 {
+		errs = append(errs, errors.New("TOKEN_DURATION must be positive"))
+	}
+
+	if config.Port < 1 || config.Port > 65535 {
+		errs = append(errs, errors.New("PORT must be between 1 and 65535"))
+	}
+
+	timeouts := []time.Duration{config.ReadTimeout, config.ReadHeaderTimeout, config.WriteTimeout, config.IdleTimeout, config.ShutdownTimeout}
+	if slices.ContainsFunc(timeouts, func(timeout time.Duration) bool { return timeout <= 0 }) {
+		errs = append(errs, errors.New("READ_TIMEOUT, READ_HEADER_TIMEOUT, WRITE_TIMEOUT, IDLE_TIMEOUT and SHUTDOWN_TIMEOUT must be positive"))
+	}
+
+	if len(config.CORSOrigins) == 0 && !config.CORSDevMode {
+		errs = append(errs, errors.New("CORS_ORIGINS must contain at least one origin"))
+	}
+
+	if config.CORSCredentials && !config.CORSDevMode && slices.Contains(config.CORSOrigins, "*") {
+		errs = append(errs, errors.New("CORS_ORIGINS cannot contain \"*\" when CORS_CREDENTIALS is enabled"))
+	}
+
+	if len(config.CORSMethods) == 0 {
+		errs = append(errs, errors.New("CORS_METHODS must contain at least one method"))
+	}
+
+	if config.RateLimit < 1 {
+		errs = append(errs, errors.New("RATE_LIMIT must be positive"))
+	}
+
+	if config.UploadDirectory == "" {
+		errs = append(errs, errors.New("UPLOAD_DIRECTORY is required"))
+	}
+
+	if config.UploadSweepInterval < 0 || config.UploadSweepGracePeriod < 0 {
+		errs = append(errs, errors.New("UPLOAD_SWEEP_INTERVAL and UPLOAD_SWEEP_GRACE_PERIOD cannot be negative"))
+	}
+
+	if config.TrashRetention <= 0 {
+		errs = append(errs, errors.New("TRASH_RETENTION must be positive"))
+	}
+
+	if config.TrashPurgeInterval < 0 {
+		errs = append(errs, errors.New("TRASH_PURGE_INTERVAL cannot be negative"))
+	}
+
+	if _, err := logging.ParseLevel(config.LogLevel); err != nil {
+		errs = append(errs, errors.New("LOG_LEVEL must be one of debug, info, warn or error"))
+	}
+
+	if config.TracingExporter != "none" && config.TracingExporter != "otlp" {
+		errs = append(errs, errors.New("TRACING_EXPORTER must be none or otlp"))
+	}
+
+	if config.TracingSampleRatio < 0 || config.TracingSampleRatio > 1 {
+		errs = append(errs, errors.New("TRACING_SAMPLE_RATIO must be between 0 and 1"))
+	}
+
+	if !slices.Contains(i18n.Supported, config.DefaultLanguage) {
+		errs = append(errs, fmt.Errorf("DEFAULT_LANGUAGE must be one of %s", strings.Join(i18n.Supported, ", ")))
+	}
+
+	return errors.Join(errs...)
+}
+
+func stringFromEnv(name string, target *string) error {
+	if value, ok := os.LookupEnv(name); ok && value != "" {
+		*target = value
+	}
+
+	return nil
+}
+
+func intFromEnv(name string, target *int) error {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("%s must be a number: %w", name, err)
+	}
+
+	*target = number
+
+	return nil
+}
+
+func floatFromEnv(name string, target *float64) error {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return nil
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("%s must be a number: %w", name, err)
+	}
+
+	*target = number
+
+	return nil
+}
+
+func boolFromEnv(name string, target *bool) error {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return nil
+	}
+
+	boolean, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("%s must be a boolean: %w", name, err)
+	}
+
+	*target = boolean
+
+	return nil
+}
+
+func durationFromEnv(name string, target *time.Duration) error {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%s must be a duration: %w", name, err)
+	}
+
+	*target = duration
+
+	return nil
+}
+
+func listFromEnv(name string, target *[]string) error {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return nil
+	}
+
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+
+	*target = list
+
+	return nil
+}
@@ -1,33 +1,54 @@
 package config
 
 import (
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-func CORSMiddleware() gin.HandlerFunc {
+func CORSMiddleware(configuration *Config) gin.HandlerFunc {
 	return cors.New(cors.Config{
-		AllowOrigins: []string{
-			"http://localhost",
-		},
-		AllowMethods: []string{
-			"GET",
-			"POST",
-			"PUT",
-			"DELETE",
-			"OPTIONS",
-		},
-		AllowHeaders: []string{
-			"Origin",
-			"Authorization",
-			"Content-Type",
+		AllowOriginFunc: func(origin string) bool {
+			// In dev mode, any origin is reflected back, so that local front-ends work on any port.
+			return configuration.CORSDevMode || OriginAllowed(configuration.CORSOrigins, origin)
 		},
+		AllowMethods: configuration.CORSMethods,
+		AllowHeaders: configuration.CORSHeaders,
 		ExposeHeaders: []string{
 			"Content-Length",
+			"ETag",
 		},
-		AllowCredentials: true,
+		AllowCredentials: configuration.CORSCredentials,
 		MaxAge:           12 * time.Hour,
 	})
 }
+
+// OriginAllowed matches an origin against exact origins, "*", or wildcard subdomain patterns such as
+// "https://*.example.com", which match "https://app.example.com" but not "https://example.com".
+func OriginAllowed(patterns []string, origin string) bool {
+	origin = strings.ToLower(origin)
+
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+
+		if pattern == "*" || pattern == origin {
+			return true
+		}
+
+		scheme, domain, found := strings.Cut(pattern, "://*.")
+		if !found || !strings.HasPrefix(origin, scheme+"://") {
+			continue
+		}
+
+		host := strings.TrimPrefix(origin, scheme+"://")
+		subdomain, matches := strings.CutSuffix(host, "."+domain)
+
+		if matches && subdomain != "" && !strings.ContainsAny(subdomain, "/:@") {
+			return true
+		}
+	}
+
+	return false
+}
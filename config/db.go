@@ -2,7 +2,8 @@ package config
 
 import (
 	"log"
-	"os"
+	"partage-projets/logging"
+	"partage-projets/tracing"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -10,14 +11,16 @@ import (
 
 var DB *gorm.DB
 
-func ConnectDB() {
-	dsn := os.Getenv("DATABASE_DSN")
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+func ConnectDB(dsn string) {
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logging.NewGormLogger()})
 
 	if err != nil {
 		log.Fatal("Unable to connect to database: ", err)
 	}
 
+	if err := db.Use(tracing.NewGormPlugin()); err != nil {
+		log.Fatal("Unable to trace database queries: ", err)
+	}
+
 	DB = db
 }
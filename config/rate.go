@@ -2,6 +2,8 @@ package config
 
 import (
 	"net/http"
+	"partage-projets/metrics"
+	"partage-projets/problem"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/time/rate"
@@ -12,7 +14,9 @@ func RateLimit(rps int) gin.HandlerFunc {
 
 	return func(context *gin.Context) {
 		if !limiter.Allow() {
-			context.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests."})
+			metrics.RateLimitRejections.Inc()
+
+			problem.Abort(context, http.StatusTooManyRequests, problem.TooManyRequests)
 
 			return
 		}
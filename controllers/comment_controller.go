@@ -2,13 +2,23 @@ package controllers
 
 import (
 	"net/http"
-	"partage-projets/config"
+	"partage-projets/i18n"
 	"partage-projets/middlewares"
 	"partage-projets/models"
+	"partage-projets/problem"
+	"partage-projets/services"
 
 	"github.com/gin-gonic/gin"
 )
 
+type CommentController struct {
+	comments *services.CommentService
+}
+
+func NewCommentController(comments *services.CommentService) *CommentController {
+	return &CommentController{comments: comments}
+}
+
 // PostComment godoc
 // @Description Ajouter un commentaire à un projet
 // @Tags Comments
@@ -16,26 +26,64 @@ import (
 // @Produce json
 // @Param comment body models.Comment true "Données du commentaire"
 // @Success 201 {object} models.Comment
-// @Failure 400 {object} map[string]string "Données invalides"
-// @Failure 500 {object} map[string]string "Erreur interne"
+// @Failure 400 {object} problem.Problem "Données invalides"
+// @Failure 404 {object} problem.Problem "Projet non trouvé"
+// @Failure 500 {object} problem.Problem "Erreur interne"
 // @Security BearerAuth
-// @Router /comments [post]
-func PostComment(context *gin.Context) {
+// @Router /api/v1/comments [post]
+func (controller *CommentController) PostComment(context *gin.Context) {
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
+
 	var comment models.Comment
 
 	if err := context.ShouldBindJSON(&comment); err != nil {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+		problem.Validation(context, err)
 
 		return
 	}
 
-	comment.UserID = *middlewares.GetUserId(context)
+	comment.UserID = *userId
 
-	if err := config.DB.Create(&comment).Error; err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create comment."})
+	if err := controller.comments.Create(context.Request.Context(), &comment); err != nil {
+		abortWithError(context, err)
 
 		return
 	}
 
 	context.JSON(http.StatusCreated, comment)
 }
+
+// DeleteComment godoc
+// @Description Supprimer un commentaire (auteur du commentaire ou propriétaire du projet uniquement)
+// @Tags Comments
+// @Produce json
+// @Param id path int true "ID du commentaire"
+// @Success 200 {object} map[string]string "Message de succès"
+// @Failure 400 {object} problem.Problem "ID invalide"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 404 {object} problem.Problem "Commentaire non trouvé"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/comments/{id} [delete]
+func (controller *CommentController) DeleteComment(context *gin.Context) {
+	id, ok := paramID(context, "id")
+	if !ok {
+		return
+	}
+
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
+
+	if err := controller.comments.Delete(context.Request.Context(), *userId, id); err != nil {
+		abortWithError(context, err)
+
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"message": i18n.T(context, "comment_deleted")})
+}
@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"partage-projets/problem"
+	"partage-projets/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var errorCodes = map[error]struct {
+	status int
+	code   problem.Code
+}{
+	services.ErrProjectNotFound:        {http.StatusNotFound, problem.ProjectNotFound},
+	services.ErrImageNotFound:          {http.StatusNotFound, problem.ImageNotFound},
+	services.ErrUserNotFound:           {http.StatusNotFound, problem.UserNotFound},
+	services.ErrForbidden:              {http.StatusForbidden, problem.Forbidden},
+	services.ErrProjectArchived:        {http.StatusConflict, problem.ProjectArchived},
+	services.ErrRestoreExpired:         {http.StatusGone, problem.RestoreExpired},
+	services.ErrCommentNotFound:        {http.StatusNotFound, problem.CommentNotFound},
+	services.ErrRevisionNotFound:       {http.StatusNotFound, problem.RevisionNotFound},
+	services.ErrVersionMismatch:        {http.StatusPreconditionFailed, problem.PreconditionFailed},
+	services.ErrEmailAlreadyUsed:       {http.StatusBadRequest, problem.EmailAlreadyUsed},
+	services.ErrInvalidCredentials:     {http.StatusBadRequest, problem.InvalidCredentials},
+	services.ErrNoDataToUpdate:         {http.StatusBadRequest, problem.NoDataToUpdate},
+	services.ErrImageRequired:          {http.StatusBadRequest, problem.ImageRequired},
+	services.ErrInvalidImageOrder:      {http.StatusBadRequest, problem.InvalidImageOrder},
+	services.ErrUnsupportedImageFormat: {http.StatusBadRequest, problem.UnsupportedImageFormat},
+	services.ErrInvalidImage:           {http.StatusBadRequest, problem.InvalidImage},
+	services.ErrUnknownRole:            {http.StatusBadRequest, problem.UnknownRole},
+}
+
+// abortWithError renders the problem matching an error returned by a service.
+func abortWithError(context *gin.Context, err error) {
+	var quotaExceeded *services.QuotaExceededError
+	if errors.As(err, &quotaExceeded) {
+		usage := quotaExceeded.Usage
+
+		// Only the exceeded limit is mentioned, the other one may be unlimited.
+		if quotaExceeded.BytesExceeded() {
+			problem.AbortWithDetail(context, http.StatusRequestEntityTooLarge, problem.QuotaExceeded, "quota_exceeded_bytes", usage.Bytes, usage.MaxBytes)
+		} else {
+			problem.AbortWithDetail(context, http.StatusRequestEntityTooLarge, problem.QuotaExceeded, "quota_exceeded_files", usage.Files, usage.MaxFiles)
+		}
+
+		return
+	}
+
+	var statusChange *services.StatusChangeError
+	if errors.As(err, &statusChange) {
+		problem.Abort(context, http.StatusConflict, problem.InvalidStatusChange, statusChange.From)
+
+		return
+	}
+
+	var fieldError *services.FieldError
+	if errors.As(err, &fieldError) {
+		code := problem.InvalidData
+		errors.As(fieldError.Err, &code)
+
+		problem.AbortWithFields(context, problem.Field(fieldError.Field, code))
+
+		return
+	}
+
+	for target, mapping := range errorCodes {
+		if errors.Is(err, target) {
+			problem.Abort(context, mapping.status, mapping.code)
+
+			return
+		}
+	}
+
+	problem.Internal(context, err)
+}
+
+// paramID reads a numeric path parameter, answering 400 if it isn't one.
+func paramID(context *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(context.Param(name), 10, 0)
+	if err != nil {
+		problem.Abort(context, http.StatusBadRequest, problem.InvalidID)
+
+		return 0, false
+	}
+
+	return uint(id), true
+}
+
+// imageFile returns the "image" part of a multipart request, or nil if none was sent.
+func imageFile(context *gin.Context) *multipart.FileHeader {
+	file, err := context.FormFile("image")
+	if err != nil {
+		return nil
+	}
+
+	return file
+}
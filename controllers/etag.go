@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"net/http"
+	"partage-projets/models"
+	"partage-projets/problem"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag renders the version of the project as its entity tag, to be sent back in If-Match to change it.
+// The tag is strong, but only covers the fields a change can edit: the gallery, likes, comments and viewer
+// also appear in the body without changing the version.
+func setETag(context *gin.Context, project *models.Project) {
+	context.Header("ETag", `"`+strconv.FormatUint(uint64(project.Version), 10)+`"`)
+}
+
+// ifMatch returns the version expected by the If-Match header, nil if there is no header or it is "*".
+// If-Match uses the strong comparison, so a weak tag never matches, like any other value that isn't a version:
+// the request is aborted with 412.
+func ifMatch(context *gin.Context) (*uint, bool) {
+	header := strings.TrimSpace(context.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, true
+	}
+
+	if len(header) > 2 && strings.HasPrefix(header, `"`) && strings.HasSuffix(header, `"`) {
+		version, err := strconv.ParseUint(header[1:len(header)-1], 10, 0)
+		if err == nil {
+			expected := uint(version)
+
+			return &expected, true
+		}
+	}
+
+	problem.Abort(context, http.StatusPreconditionFailed, problem.PreconditionFailed)
+
+	return nil, false
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"partage-projets/config"
+	"partage-projets/migrations"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type Check struct {
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Version string `json:"version,omitempty"`
+	Pending *int   `json:"pending,omitempty"`
+}
+
+type Readiness struct {
+	Status string           `json:"status"`
+	Checks map[string]Check `json:"checks"`
+}
+
+type HealthController struct {
+	db        *gorm.DB
+	directory string
+}
+
+func NewHealthController(db *gorm.DB, directory string) *HealthController {
+	return &HealthController{db: db, directory: directory}
+}
+
+// Healthz godoc
+// @Description Vérifier que le processus est en vie
+// @Tags Health
+// @Produce json
+// @Success 200 {object} map[string]string "Processus en vie"
+// @Router /healthz [get]
+func (controller *HealthController) Healthz(context *gin.Context) {
+	context.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readyz godoc
+// @Description Vérifier que l'application est prête à recevoir du trafic (base de données, stockage, migrations)
+// @Tags Health
+// @Produce json
+// @Success 200 {object} controllers.Readiness
+// @Failure 503 {object} controllers.Readiness "Au moins une vérification a échoué"
+// @Router /readyz [get]
+func (controller *HealthController) Readyz(context *gin.Context) {
+	readiness := Readiness{
+		Status: "ok",
+		Checks: map[string]Check{
+			"database":   checkDatabase(context.Request.Context(), controller.db),
+			"storage":    checkStorage(context.Request.Context(), controller.directory),
+			"migrations": checkMigrations(context.Request.Context(), controller.db),
+		},
+	}
+
+	status := http.StatusOK
+
+	for _, check := range readiness.Checks {
+		if check.Status != "ok" {
+			readiness.Status = "error"
+			status = http.StatusServiceUnavailable
+		}
+	}
+
+	context.JSON(status, readiness)
+}
+
+// GetBuildInfo godoc
+// @Description Récupérer la version et le commit de l'application
+// @Tags Health
+// @Produce json
+// @Success 200 {object} config.BuildInfo
+// @Router /version [get]
+func (controller *HealthController) GetBuildInfo(context *gin.Context) {
+	context.JSON(http.StatusOK, config.Build())
+}
+
+func checkDatabase(ctx context.Context, db *gorm.DB) Check {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return failedCheck(ctx, "database", "database unavailable", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return failedCheck(ctx, "database", "database unreachable", err)
+	}
+
+	return Check{Status: "ok"}
+}
+
+func checkStorage(ctx context.Context, directory string) Check {
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return failedCheck(ctx, "storage", "upload directory unavailable", err)
+	}
+
+	file, err := os.CreateTemp(directory, ".readyz-*")
+	if err != nil {
+		return failedCheck(ctx, "storage", "upload directory not writable", err)
+	}
+
+	file.Close()
+
+	if err := os.Remove(file.Name()); err != nil {
+		return failedCheck(ctx, "storage", "upload directory not writable", err)
+	}
+
+	return Check{Status: "ok"}
+}
+
+func checkMigrations(ctx context.Context, db *gorm.DB) Check {
+	version, pending, err := migrations.Inspect(db.WithContext(ctx))
+	if err != nil {
+		return failedCheck(ctx, "migrations", "migrations unreadable", err)
+	}
+
+	check := Check{Status: "ok", Version: version, Pending: &pending}
+
+	if pending > 0 {
+		check.Status = "error"
+		check.Error = "migrations are pending"
+	}
+
+	return check
+}
+
+// failedCheck logs the error and reports the check as failed with a generic message, since the probe is public
+// and errors may disclose details such as the database host or the paths of the server.
+func failedCheck(ctx context.Context, name string, message string, err error) Check {
+	slog.ErrorContext(ctx, "readiness check failed", slog.String("check", name), slog.Any("error", err))
+
+	return Check{Status: "error", Error: message}
+}
@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"partage-projets/problem"
+	"partage-projets/utils"
+	"path/filepath"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MediaController serves the files stored in the upload directory.
+type MediaController struct {
+	directory string
+}
+
+func NewMediaController(directory string) *MediaController {
+	return &MediaController{directory: directory}
+}
+
+// GetMedia godoc
+// @Description Récupérer un fichier envoyé (image d'un projet)
+// @Tags Media
+// @Produce octet-stream
+// @Param filepath path string true "Nom du fichier"
+// @Param Range header string false "Plage d'octets demandée"
+// @Param If-None-Match header string false "ETag déjà connu"
+// @Success 200 {file} file "Contenu du fichier"
+// @Success 206 {file} file "Contenu partiel du fichier"
+// @Success 304 "Fichier non modifié"
+// @Failure 404 {object} problem.Problem "Fichier non trouvé"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Router /media/{filepath} [get]
+func (controller *MediaController) GetMedia(context *gin.Context) {
+	// Cleaning the rooted path removes any ".." that would escape the upload directory.
+	name := filepath.Clean("/" + context.Param("filepath"))
+	path := filepath.Join(controller.directory, name)
+
+	file, err := os.Open(path)
+	if err != nil {
+		problem.Abort(context, http.StatusNotFound, problem.MediaNotFound)
+
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		problem.Abort(context, http.StatusNotFound, problem.MediaNotFound)
+
+		return
+	}
+
+	etag, err := utils.MediaETag(name, file)
+	if err != nil {
+		problem.Internal(context, err)
+
+		return
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		problem.Internal(context, err)
+
+		return
+	}
+
+	context.Header("ETag", etag)
+
+	if utils.IsContentAddressed(name) {
+		context.Header("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		context.Header("Cache-Control", "public, no-cache")
+	}
+
+	// ServeContent handles HEAD, Range and conditional requests against the ETag set above.
+	http.ServeContent(context.Writer, context.Request, info.Name(), info.ModTime(), file)
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"net/http"
+	"partage-projets/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PutProjectCollaborator godoc
+// @Description Ajouter un collaborateur à un projet, qui peut alors le voir et le modifier même s'il est privé (propriétaire uniquement)
+// @Tags Projects
+// @Produce json
+// @Param id path int true "ID du projet"
+// @Param userId path int true "ID de l'utilisateur"
+// @Success 200 {object} models.Project
+// @Failure 400 {object} problem.Problem "ID invalide"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 404 {object} problem.Problem "Projet ou utilisateur non trouvé"
+// @Failure 409 {object} problem.Problem "Projet archivé"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/projects/{id}/collaborators/{userId} [put]
+func (controller *ProjectController) PutProjectCollaborator(context *gin.Context) {
+	id, ok := paramID(context, "id")
+	if !ok {
+		return
+	}
+
+	collaboratorID, ok := paramID(context, "userId")
+	if !ok {
+		return
+	}
+
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
+
+	project, err := controller.projects.AddCollaborator(context.Request.Context(), *userId, id, collaboratorID)
+	if err != nil {
+		abortWithError(context, err)
+
+		return
+	}
+
+	setETag(context, project)
+	context.JSON(http.StatusOK, project)
+}
+
+// DeleteProjectCollaborator godoc
+// @Description Retirer un collaborateur d'un projet (propriétaire uniquement)
+// @Tags Projects
+// @Produce json
+// @Param id path int true "ID du projet"
+// @Param userId path int true "ID de l'utilisateur"
+// @Success 200 {object} models.Project
+// @Failure 400 {object} problem.Problem "ID invalide"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 404 {object} problem.Problem "Projet non trouvé"
+// @Failure 409 {object} problem.Problem "Projet archivé"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/projects/{id}/collaborators/{userId} [delete]
+func (controller *ProjectController) DeleteProjectCollaborator(context *gin.Context) {
+	id, ok := paramID(context, "id")
+	if !ok {
+		return
+	}
+
+	collaboratorID, ok := paramID(context, "userId")
+	if !ok {
+		return
+	}
+
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
+
+	project, err := controller.projects.RemoveCollaborator(context.Request.Context(), *userId, id, collaboratorID)
+	if err != nil {
+		abortWithError(context, err)
+
+		return
+	}
+
+	setETag(context, project)
+	context.JSON(http.StatusOK, project)
+}
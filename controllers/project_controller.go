@@ -2,28 +2,37 @@ package controllers
 
 import (
 	"net/http"
-	"os"
-	"partage-projets/config"
+	"partage-projets/i18n"
 	"partage-projets/middlewares"
 	"partage-projets/models"
+	"partage-projets/problem"
+	"partage-projets/services"
 	"partage-projets/utils"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/datatypes"
 )
 
+type ProjectController struct {
+	projects *services.ProjectService
+}
+
+func NewProjectController(projects *services.ProjectService) *ProjectController {
+	return &ProjectController{projects: projects}
+}
+
 // GetProjects godoc
-// @Description Récupérer tous les projets
+// @Description Récupérer tous les projets (authentification facultative, "liked_by_me" n'étant renseigné que pour un utilisateur connecté)
 // @Tags Projects
 // @Produce json
 // @Success 200 {array} models.Project
+// @Failure 401 {object} problem.Problem "Token invalide"
 // @Security BearerAuth
-// @Router /projects [get]
-func GetProjects(context *gin.Context) {
-	var projects []models.Project
+// @Router /api/v1/projects [get]
+func (controller *ProjectController) GetProjects(context *gin.Context) {
+	projects, err := controller.projects.List(context.Request.Context(), middlewares.GetOptionalUserId(context))
+	if err != nil {
+		problem.Internal(context, err)
 
-	if err := config.DB.Preload("Likes").Preload("Comments").Find(&projects).Error; err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch projects."})
 		return
 	}
 
@@ -31,158 +40,169 @@ func GetProjects(context *gin.Context) {
 }
 
 // GetProject godoc
-// @Description Récupérer un projet par son ID
+// @Description Récupérer un projet par son ID (authentification facultative, "liked_by_me" n'étant renseigné que pour un utilisateur connecté)
 // @Tags Projects
 // @Produce json
 // @Param id path int true "ID du projet"
 // @Success 200 {object} models.Project
-// @Failure 400 {object} map[string]string "ID invalide"
-// @Failure 404 {object} map[string]string "Projet non trouvé"
-// @Failure 500 {object} map[string]string "Erreur interne"
+// @Failure 400 {object} problem.Problem "ID invalide"
+// @Failure 401 {object} problem.Problem "Token invalide"
+// @Failure 404 {object} problem.Problem "Projet non trouvé"
+// @Failure 500 {object} problem.Problem "Erreur interne"
 // @Security BearerAuth
-// @Router /projects/{id} [get]
-func GetProject(context *gin.Context) {
-	project, err := models.FindProjectById(context)
+// @Header 200 {string} ETag "Version du projet, à renvoyer dans If-Match pour le modifier"
+// @Router /api/v1/projects/{id} [get]
+func (controller *ProjectController) GetProject(context *gin.Context) {
+	id, ok := paramID(context, "id")
+	if !ok {
+		return
+	}
+
+	project, err := controller.projects.Get(context.Request.Context(), id, middlewares.GetOptionalUserId(context))
+	if err != nil {
+		abortWithError(context, err)
 
-	if err == nil {
-		context.JSON(http.StatusOK, project)
+		return
 	}
+
+	setETag(context, project)
+	context.JSON(http.StatusOK, project)
 }
 
 // PostProject godoc
-// @Description Créer un nouveau projet
+// @Description Créer un nouveau projet (en JSON, ou en multipart avec une partie JSON "data" ou des champs de formulaire, et une image)
 // @Tags Projects
-// @Accept json
+// @Accept json,mpfd
 // @Produce json
-// @Param project body models.Project true "Données du projet"
+// @Param project body models.ProjectCreateInput true "Données du projet"
+// @Param image formData file false "Image du projet (multipart uniquement)"
 // @Success 201 {object} models.Project
-// @Failure 400 {object} map[string]string "Données invalides"
-// @Failure 500 {object} map[string]string "Erreur interne"
+// @Failure 400 {object} problem.Problem "Données invalides"
+// @Failure 413 {object} problem.Problem "Quota de stockage dépassé"
+// @Failure 500 {object} problem.Problem "Erreur interne"
 // @Security BearerAuth
-// @Router /projects [post]
-func PostProject(context *gin.Context) {
-	var project models.Project
-
-	if err := context.ShouldBindJSON(&project); err != nil {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
-
+// @Router /api/v1/projects [post]
+func (controller *ProjectController) PostProject(context *gin.Context) {
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
 		return
 	}
 
-	path, err := utils.UploadImage(context)
-	if err != nil {
-		return
-	}
+	var input models.ProjectCreateInput
 
-	if path != nil {
-		project.Image = *path
+	if err := utils.BindData(context, &input); err != nil {
+		problem.Validation(context, err)
+
+		return
 	}
 
-	if err := config.DB.Create(&project).Error; err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create project."})
+	project, err := controller.projects.Create(context.Request.Context(), *userId, input, imageFile(context))
+	if err != nil {
+		abortWithError(context, err)
 
 		return
 	}
 
+	setETag(context, project)
 	context.JSON(http.StatusCreated, project)
 }
 
 // PutProject godoc
-// @Description Mettre à jour un projet existant
+// @Description Mettre à jour un projet existant (en JSON, ou en multipart avec une partie JSON "data" ou des champs de formulaire, et une image)
+// @Description L'en-tête If-Match, recommandé, évite d'écraser les modifications d'un autre utilisateur
 // @Tags Projects
-// @Accept json
+// @Accept json,mpfd
 // @Produce json
 // @Param id path int true "ID du projet"
+// @Param If-Match header string false "ETag du projet lu avant la modification"
 // @Param input body models.ProjectUpdateInput true "Données de mise à jour"
+// @Param image formData file false "Nouvelle image du projet (multipart uniquement)"
 // @Success 200 {object} models.Project
-// @Failure 400 {object} map[string]string "Données invalides"
-// @Failure 404 {object} map[string]string "Projet non trouvé"
-// @Failure 500 {object} map[string]string "Erreur interne"
+// @Failure 400 {object} problem.Problem "Données invalides"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 404 {object} problem.Problem "Projet non trouvé"
+// @Failure 409 {object} problem.Problem "Projet archivé"
+// @Failure 412 {object} problem.Problem "Projet modifié depuis sa lecture"
+// @Failure 413 {object} problem.Problem "Quota de stockage dépassé"
+// @Failure 428 {object} problem.Problem "En-tête If-Match manquant, s'il est obligatoire"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Header 200 {string} ETag "Nouvelle version du projet"
 // @Security BearerAuth
-// @Router /projects/{id} [put]
-func PutProject(context *gin.Context) {
-	project, err := models.FindProjectById(context)
-
-	if err == nil {
-		var input models.ProjectUpdateInput
-		if err = context.ShouldBindJSON(&input); err != nil {
-			context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
-
-			return
-		}
-
-		updates := make(map[string]interface{})
-
-		if input.Name != nil {
-			updates["name"] = *input.Name
-		}
-
-		if input.Description != nil {
-			updates["description"] = *input.Description
-		}
-
-		path, err := utils.UploadImage(context)
-		if err != nil {
-			return
-		}
-
-		if path != nil {
-			if project.Image != "" {
-				err = os.Remove(project.Image)
-
-				if err != nil {
-					context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to delete old image."})
-
-					return
-				}
-			}
+// @Router /api/v1/projects/{id} [put]
+func (controller *ProjectController) PutProject(context *gin.Context) {
+	id, ok := paramID(context, "id")
+	if !ok {
+		return
+	}
 
-			updates["image"] = *path
-		}
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
 
-		if input.Skills != nil {
-			updates["skills"] = datatypes.JSONSlice[string](*input.Skills)
-		}
+	version, ok := ifMatch(context)
+	if !ok {
+		return
+	}
 
-		if len(updates) == 0 {
-			context.JSON(http.StatusBadRequest, gin.H{"error": "No data to update."})
+	var input models.ProjectUpdateInput
 
-			return
-		}
+	if err := utils.BindData(context, &input); err != nil {
+		problem.Validation(context, err)
 
-		if err := config.DB.Model(&project).Updates(updates).Error; err != nil {
-			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to update project."})
+		return
+	}
 
-			return
-		}
+	project, err := controller.projects.Update(context.Request.Context(), *userId, id, version, input, imageFile(context))
+	if err != nil {
+		abortWithError(context, err)
 
-		context.JSON(http.StatusOK, project)
+		return
 	}
+
+	setETag(context, project)
+	context.JSON(http.StatusOK, project)
 }
 
 // DeleteProject godoc
-// @Description Supprimer un projet
+// @Description Supprimer un projet, qui est placé dans la corbeille de son propriétaire
 // @Tags Projects
 // @Produce json
 // @Param id path int true "ID du projet"
+// @Param If-Match header string false "ETag du projet lu avant la suppression"
 // @Success 200 {object} map[string]string "Message de succès"
-// @Failure 400 {object} map[string]string "ID invalide"
-// @Failure 404 {object} map[string]string "Projet non trouvé"
-// @Failure 500 {object} map[string]string "Erreur interne"
+// @Failure 400 {object} problem.Problem "ID invalide"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 404 {object} problem.Problem "Projet non trouvé"
+// @Failure 409 {object} problem.Problem "Projet archivé"
+// @Failure 412 {object} problem.Problem "Projet modifié depuis sa lecture"
+// @Failure 428 {object} problem.Problem "En-tête If-Match manquant, s'il est obligatoire"
+// @Failure 500 {object} problem.Problem "Erreur interne"
 // @Security BearerAuth
-// @Router /projects/{id} [delete]
-func DeleteProject(context *gin.Context) {
-	project, err := models.FindProjectById(context)
+// @Router /api/v1/projects/{id} [delete]
+func (controller *ProjectController) DeleteProject(context *gin.Context) {
+	id, ok := paramID(context, "id")
+	if !ok {
+		return
+	}
 
-	if err == nil {
-		if err = config.DB.Delete(&project).Error; err != nil {
-			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to delete project."})
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
+
+	version, ok := ifMatch(context)
+	if !ok {
+		return
+	}
 
-			return
-		}
+	if err := controller.projects.Delete(context.Request.Context(), *userId, id, version); err != nil {
+		abortWithError(context, err)
 
-		context.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully."})
+		return
 	}
+
+	context.JSON(http.StatusOK, gin.H{"message": i18n.T(context, "project_deleted")})
 }
 
 // LikeProject godoc
@@ -191,49 +211,32 @@ func DeleteProject(context *gin.Context) {
 // @Produce json
 // @Param id path int true "ID du projet"
 // @Success 200 {object} map[string]string "Message de succès"
-// @Failure 400 {object} map[string]string "ID invalide"
-// @Failure 404 {object} map[string]string "Projet non trouvé"
-// @Failure 500 {object} map[string]string "Erreur interne"
+// @Failure 400 {object} problem.Problem "ID invalide"
+// @Failure 404 {object} problem.Problem "Projet non trouvé"
+// @Failure 500 {object} problem.Problem "Erreur interne"
 // @Security BearerAuth
-// @Router /projects/{id}/like [put]
-func LikeProject(context *gin.Context) {
-	var user models.User
-
-	project, err := models.FindProjectById(context)
-
-	if err == nil {
-		userId := middlewares.GetUserId(context)
-
-		if err := config.DB.First(&user, userId).Error; err != nil {
-			context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch user."})
-
-			return
-		}
-
-		liked := false
-		for _, u := range project.Likes {
-			if u.ID == user.ID {
-				liked = true
-				break
-			}
-		}
-
-		if liked {
-			if err := config.DB.Model(&project).Association("Likes").Delete(&user); err != nil {
-				context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to unlike project."})
+// @Router /api/v1/projects/{id}/like [put]
+func (controller *ProjectController) LikeProject(context *gin.Context) {
+	id, ok := paramID(context, "id")
+	if !ok {
+		return
+	}
 
-				return
-			}
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
 
-			context.JSON(http.StatusOK, gin.H{"message": "Project unliked successfully."})
-		} else {
-			if err := config.DB.Model(&project).Association("Likes").Append(&user); err != nil {
-				context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to like project."})
+	liked, err := controller.projects.ToggleLike(context.Request.Context(), *userId, id)
+	if err != nil {
+		abortWithError(context, err)
 
-				return
-			}
+		return
+	}
 
-			context.JSON(http.StatusOK, gin.H{"message": "Project liked successfully."})
-		}
+	if liked {
+		context.JSON(http.StatusOK, gin.H{"message": i18n.T(context, "project_liked")})
+	} else {
+		context.JSON(http.StatusOK, gin.H{"message": i18n.T(context, "project_unliked")})
 	}
 }
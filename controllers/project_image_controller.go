@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"net/http"
+	"partage-projets/i18n"
+	"partage-projets/middlewares"
+	"partage-projets/models"
+	"partage-projets/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PostProjectImage godoc
+// @Description Ajouter une image à la galerie d'un projet
+// @Tags Projects
+// @Accept mpfd
+// @Produce json
+// @Param id path int true "ID du projet"
+// @Param image formData file true "Image"
+// @Param caption formData string false "Légende"
+// @Param alt_text formData string false "Texte alternatif"
+// @Success 201 {object} models.ProjectImage
+// @Failure 400 {object} problem.Problem "Données invalides"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 404 {object} problem.Problem "Projet non trouvé"
+// @Failure 413 {object} problem.Problem "Quota de stockage dépassé"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/projects/{id}/images [post]
+func (controller *ProjectController) PostProjectImage(context *gin.Context) {
+	id, ok := paramID(context, "id")
+	if !ok {
+		return
+	}
+
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
+
+	var input models.ProjectImageInput
+
+	if err := context.ShouldBind(&input); err != nil {
+		problem.Validation(context, err)
+
+		return
+	}
+
+	image, err := controller.projects.AddImage(context.Request.Context(), *userId, id, input, imageFile(context))
+	if err != nil {
+		abortWithError(context, err)
+
+		return
+	}
+
+	context.JSON(http.StatusCreated, image)
+}
+
+// PutProjectImagesOrder godoc
+// @Description Réordonner les images de la galerie d'un projet
+// @Tags Projects
+// @Accept json
+// @Produce json
+// @Param id path int true "ID du projet"
+// @Param input body models.ProjectImagesOrderInput true "IDs de toutes les images, dans le nouvel ordre"
+// @Success 200 {array} models.ProjectImage
+// @Failure 400 {object} problem.Problem "Données invalides"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 404 {object} problem.Problem "Projet non trouvé"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/projects/{id}/images/order [put]
+func (controller *ProjectController) PutProjectImagesOrder(context *gin.Context) {
+	id, ok := paramID(context, "id")
+	if !ok {
+		return
+	}
+
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
+
+	var input models.ProjectImagesOrderInput
+
+	if err := context.ShouldBindJSON(&input); err != nil {
+		problem.Validation(context, err)
+
+		return
+	}
+
+	images, err := controller.projects.ReorderImages(context.Request.Context(), *userId, id, input.ImageIDs)
+	if err != nil {
+		abortWithError(context, err)
+
+		return
+	}
+
+	context.JSON(http.StatusOK, images)
+}
+
+// PutProjectImageCover godoc
+// @Description Définir l'image de couverture d'un projet
+// @Tags Projects
+// @Produce json
+// @Param id path int true "ID du projet"
+// @Param imageId path int true "ID de l'image"
+// @Success 200 {object} models.ProjectImage
+// @Failure 400 {object} problem.Problem "ID invalide"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 404 {object} problem.Problem "Projet ou image non trouvé"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/projects/{id}/images/{imageId}/cover [put]
+func (controller *ProjectController) PutProjectImageCover(context *gin.Context) {
+	id, ok := paramID(context, "id")
+	if !ok {
+		return
+	}
+
+	imageID, ok := paramID(context, "imageId")
+	if !ok {
+		return
+	}
+
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
+
+	image, err := controller.projects.SetCover(context.Request.Context(), *userId, id, imageID)
+	if err != nil {
+		abortWithError(context, err)
+
+		return
+	}
+
+	context.JSON(http.StatusOK, image)
+}
+
+// DeleteProjectImage godoc
+// @Description Supprimer une image de la galerie d'un projet
+// @Tags Projects
+// @Produce json
+// @Param id path int true "ID du projet"
+// @Param imageId path int true "ID de l'image"
+// @Success 200 {object} map[string]string "Message de succès"
+// @Failure 400 {object} problem.Problem "ID invalide"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 404 {object} problem.Problem "Projet ou image non trouvé"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/projects/{id}/images/{imageId} [delete]
+func (controller *ProjectController) DeleteProjectImage(context *gin.Context) {
+	id, ok := paramID(context, "id")
+	if !ok {
+		return
+	}
+
+	imageID, ok := paramID(context, "imageId")
+	if !ok {
+		return
+	}
+
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
+
+	if err := controller.projects.DeleteImage(context.Request.Context(), *userId, id, imageID); err != nil {
+		abortWithError(context, err)
+
+		return
+	}
+
+	context.JSON(http.StatusOK, gin.H{"message": i18n.T(context, "image_deleted")})
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"net/http"
+	"partage-projets/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetProjectRevisions godoc
+// @Description Récupérer l'historique des modifications d'un projet, de la plus récente à la plus ancienne, avec les champs modifiés (membres du projet uniquement)
+// @Tags Projects
+// @Produce json
+// @Param id path int true "ID du projet"
+// @Success 200 {array} models.ProjectRevision
+// @Failure 400 {object} problem.Problem "ID invalide"
+// @Failure 401 {object} problem.Problem "Token invalide"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 404 {object} problem.Problem "Projet non trouvé"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/projects/{id}/revisions [get]
+func (controller *ProjectController) GetProjectRevisions(context *gin.Context) {
+	id, ok := paramID(context, "id")
+	if !ok {
+		return
+	}
+
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
+
+	revisions, err := controller.projects.Revisions(context.Request.Context(), *userId, id)
+	if err != nil {
+		abortWithError(context, err)
+
+		return
+	}
+
+	context.JSON(http.StatusOK, revisions)
+}
+
+// RestoreProjectRevision godoc
+// @Description Rétablir un projet dans l'état d'une révision, ce qui crée une nouvelle révision (propriétaire uniquement)
+// @Tags Projects
+// @Produce json
+// @Param id path int true "ID du projet"
+// @Param revisionId path int true "ID de la révision"
+// @Success 200 {object} models.Project
+// @Failure 400 {object} problem.Problem "ID invalide"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 404 {object} problem.Problem "Projet ou révision non trouvé"
+// @Failure 409 {object} problem.Problem "Projet archivé"
+// @Failure 413 {object} problem.Problem "Quota de stockage dépassé par l'image de la révision"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/projects/{id}/revisions/{revisionId}/restore [post]
+func (controller *ProjectController) RestoreProjectRevision(context *gin.Context) {
+	id, ok := paramID(context, "id")
+	if !ok {
+		return
+	}
+
+	revisionID, ok := paramID(context, "revisionId")
+	if !ok {
+		return
+	}
+
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
+
+	project, err := controller.projects.RestoreRevision(context.Request.Context(), *userId, id, revisionID)
+	if err != nil {
+		abortWithError(context, err)
+
+		return
+	}
+
+	setETag(context, project)
+	context.JSON(http.StatusOK, project)
+}
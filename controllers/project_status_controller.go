@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"partage-projets/middlewares"
+	"partage-projets/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PublishProject godoc
+// @Description Publier un projet en brouillon, ou republier un projet archivé (propriétaire uniquement)
+// @Tags Projects
+// @Produce json
+// @Param id path int true "ID du projet"
+// @Success 200 {object} models.Project
+// @Failure 400 {object} problem.Problem "ID invalide"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 404 {object} problem.Problem "Projet non trouvé"
+// @Failure 409 {object} problem.Problem "Projet déjà publié"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/projects/{id}/publish [put]
+func (controller *ProjectController) PublishProject(context *gin.Context) {
+	controller.changeStatus(context, controller.projects.Publish)
+}
+
+// UnpublishProject godoc
+// @Description Repasser un projet publié en brouillon (propriétaire uniquement)
+// @Tags Projects
+// @Produce json
+// @Param id path int true "ID du projet"
+// @Success 200 {object} models.Project
+// @Failure 400 {object} problem.Problem "ID invalide"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 404 {object} problem.Problem "Projet non trouvé"
+// @Failure 409 {object} problem.Problem "Projet non publié"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/projects/{id}/unpublish [put]
+func (controller *ProjectController) UnpublishProject(context *gin.Context) {
+	controller.changeStatus(context, controller.projects.Unpublish)
+}
+
+// ArchiveProject godoc
+// @Description Archiver un projet publié, qui reste visible mais ne peut plus être modifié, liké ni commenté (propriétaire uniquement)
+// @Tags Projects
+// @Produce json
+// @Param id path int true "ID du projet"
+// @Success 200 {object} models.Project
+// @Failure 400 {object} problem.Problem "ID invalide"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 404 {object} problem.Problem "Projet non trouvé"
+// @Failure 409 {object} problem.Problem "Projet non publié"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/projects/{id}/archive [put]
+func (controller *ProjectController) ArchiveProject(context *gin.Context) {
+	controller.changeStatus(context, controller.projects.Archive)
+}
+
+func (controller *ProjectController) changeStatus(context *gin.Context, change func(ctx context.Context, userID uint, id uint) (*models.Project, error)) {
+	id, ok := paramID(context, "id")
+	if !ok {
+		return
+	}
+
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
+
+	project, err := change(context.Request.Context(), *userId, id)
+	if err != nil {
+		abortWithError(context, err)
+
+		return
+	}
+
+	setETag(context, project)
+	context.JSON(http.StatusOK, project)
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"net/http"
+	"partage-projets/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTrash godoc
+// @Description Récupérer les projets supprimés dont l'utilisateur est propriétaire, du plus récent au plus ancien
+// @Tags Users
+// @Produce json
+// @Success 200 {array} models.Project
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/users/me/trash [get]
+func (controller *ProjectController) GetTrash(context *gin.Context) {
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
+
+	projects, err := controller.projects.Trash(context.Request.Context(), *userId)
+	if err != nil {
+		abortWithError(context, err)
+
+		return
+	}
+
+	context.JSON(http.StatusOK, projects)
+}
+
+// RestoreProject godoc
+// @Description Restaurer un projet depuis la corbeille, tant que la durée de rétention n'est pas écoulée (propriétaire uniquement)
+// @Tags Projects
+// @Produce json
+// @Param id path int true "ID du projet"
+// @Success 200 {object} models.Project
+// @Failure 400 {object} problem.Problem "ID invalide"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 404 {object} problem.Problem "Projet non trouvé dans la corbeille"
+// @Failure 410 {object} problem.Problem "Durée de rétention écoulée"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/projects/{id}/restore [post]
+func (controller *ProjectController) RestoreProject(context *gin.Context) {
+	id, ok := paramID(context, "id")
+	if !ok {
+		return
+	}
+
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
+		return
+	}
+
+	project, err := controller.projects.Restore(context.Request.Context(), *userId, id)
+	if err != nil {
+		abortWithError(context, err)
+
+		return
+	}
+
+	setETag(context, project)
+	context.JSON(http.StatusOK, project)
+}
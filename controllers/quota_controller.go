@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"net/http"
+	"partage-projets/models"
+	"partage-projets/problem"
+	"partage-projets/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QuotaController struct {
+	uploads *services.UploadService
+}
+
+func NewQuotaController(uploads *services.UploadService) *QuotaController {
+	return &QuotaController{uploads: uploads}
+}
+
+// GetQuotas godoc
+// @Description Récupérer les quotas d'envoi de fichiers configurés par rôle (administrateurs uniquement)
+// @Tags Admin
+// @Produce json
+// @Success 200 {array} models.RoleQuota
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/admin/quotas [get]
+func (controller *QuotaController) GetQuotas(context *gin.Context) {
+	quotas, err := controller.uploads.Quotas(context.Request.Context())
+	if err != nil {
+		problem.Internal(context, err)
+
+		return
+	}
+
+	context.JSON(http.StatusOK, quotas)
+}
+
+// PutQuota godoc
+// @Description Définir le quota d'envoi de fichiers d'un rôle, 0 signifiant illimité (administrateurs uniquement)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param role path string true "Rôle (user, admin)"
+// @Param quota body models.RoleQuota true "Quota (max_bytes, max_files)"
+// @Success 200 {object} models.RoleQuota
+// @Failure 400 {object} problem.Problem "Données invalides"
+// @Failure 403 {object} problem.Problem "Accès refusé"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/admin/quotas/{role} [put]
+func (controller *QuotaController) PutQuota(context *gin.Context) {
+	var quota models.RoleQuota
+
+	if err := context.ShouldBindJSON(&quota); err != nil {
+		problem.Validation(context, err)
+
+		return
+	}
+
+	quota.Role = context.Param("role")
+
+	if err := controller.uploads.SaveQuota(context.Request.Context(), &quota); err != nil {
+		abortWithError(context, err)
+
+		return
+	}
+
+	context.JSON(http.StatusOK, quota)
+}
@@ -2,20 +2,22 @@ package controllers
 
 import (
 	"net/http"
-	"os"
-	"partage-projets/config"
+	"partage-projets/i18n"
+	"partage-projets/middlewares"
 	"partage-projets/models"
-	"partage-projets/utils"
-	"time"
+	"partage-projets/problem"
+	"partage-projets/services"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
-	"golang.org/x/crypto/bcrypt"
 )
 
-type CustomClaim struct {
-	UserID uint
-	jwt.RegisteredClaims
+type UserController struct {
+	users   *services.UserService
+	uploads *services.UploadService
+}
+
+func NewUserController(users *services.UserService, uploads *services.UploadService) *UserController {
+	return &UserController{users: users, uploads: uploads}
 }
 
 // Login godoc
@@ -25,49 +27,26 @@ type CustomClaim struct {
 // @Produce json
 // @Param user body models.User true "Identifiants utilisateur (email, password)"
 // @Success 200 {object} map[string]string "Token JWT"
-// @Failure 400 {object} map[string]string "Identifiants invalides"
-// @Failure 500 {object} map[string]string "Erreur interne"
-// @Router /users/login [post]
-func Login(context *gin.Context) {
+// @Failure 400 {object} problem.Problem "Identifiants invalides"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Router /api/v1/users/login [post]
+func (controller *UserController) Login(context *gin.Context) {
 	var user models.User
 
 	if err := context.ShouldBindJSON(&user); err != nil {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
-
-		return
-	}
-
-	var existingUser models.User
-	if err := config.DB.Where("email = ?", user.Email).First(&existingUser).Error; err != nil {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid email or password."})
+		problem.Validation(context, err)
 
 		return
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(existingUser.Password), []byte(user.Password)); err != nil {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid email or password."})
-
-		return
-	}
-
-	claim := &CustomClaim{
-		UserID: existingUser.ID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(2 * time.Hour)),
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claim)
-
-	tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
-
+	token, err := controller.users.Login(context.Request.Context(), user.Email, user.Password)
 	if err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to generate token."})
+		abortWithError(context, err)
 
 		return
 	}
 
-	context.JSON(http.StatusOK, gin.H{"token": tokenString})
+	context.JSON(http.StatusOK, gin.H{"token": token})
 }
 
 // Register godoc
@@ -77,48 +56,47 @@ func Login(context *gin.Context) {
 // @Produce json
 // @Param user body models.User true "Données utilisateur (email, password)"
 // @Success 201 {object} map[string]string "Message de succès"
-// @Failure 400 {object} map[string]string "Données invalides"
-// @Failure 500 {object} map[string]string "Erreur interne"
-// @Router /users/register [post]
-func Register(context *gin.Context) {
+// @Failure 400 {object} problem.Problem "Données invalides"
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Router /api/v1/users/register [post]
+func (controller *UserController) Register(context *gin.Context) {
 	var user models.User
 
 	if err := context.ShouldBindJSON(&user); err != nil {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid data."})
+		problem.Validation(context, err)
 
 		return
 	}
 
-	var count int64
-	config.DB.Model(&models.User{}).Where("email = ?", user.Email).Count(&count)
-
-	if count > 0 {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "Email already used."})
+	if err := controller.users.Register(context.Request.Context(), &user); err != nil {
+		abortWithError(context, err)
 
 		return
 	}
 
-	if err := utils.ValidatePassword(user.Password); err != nil {
-		context.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	context.JSON(http.StatusCreated, gin.H{"message": i18n.T(context, "user_created")})
+}
 
+// GetUsage godoc
+// @Description Récupérer l'espace de stockage utilisé par l'utilisateur connecté, et ses quotas
+// @Tags Users
+// @Produce json
+// @Success 200 {object} models.Usage
+// @Failure 500 {object} problem.Problem "Erreur interne"
+// @Security BearerAuth
+// @Router /api/v1/users/me/usage [get]
+func (controller *UserController) GetUsage(context *gin.Context) {
+	userId := middlewares.GetUserId(context)
+	if userId == nil {
 		return
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
-
+	usage, err := controller.uploads.Usage(context.Request.Context(), *userId)
 	if err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to hash password."})
-
-		return
-	}
-
-	user.Password = string(hashedPassword)
-
-	if err := config.DB.Create(&user).Error; err != nil {
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to create user."})
+		problem.Internal(context, err)
 
 		return
 	}
 
-	context.JSON(http.StatusCreated, gin.H{"message": "User created successfully."})
+	context.JSON(http.StatusOK, usage)
 }
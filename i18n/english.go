@@ -0,0 +1,53 @@
+package i18n
+
+var english = map[string]string{
+	"invalid_data":               "Invalid data.",
+	"invalid_id":                 "Invalid ID.",
+	"no_data_to_update":          "No data to update.",
+	"invalid_credentials":        "Invalid email or password.",
+	"email_already_used":         "Email already used.",
+	"unauthorized":               "Unauthorized.",
+	"invalid_token":              "Invalid or expired token.",
+	"forbidden":                  "Forbidden.",
+	"route_not_found":            "Route not found.",
+	"project_not_found":          "Project not found.",
+	"project_archived":           "This project is archived and can no longer be changed.",
+	"invalid_status_change":      "This change of status is not allowed for a %s project.",
+	"restore_expired":            "This project was deleted too long ago to be restored.",
+	"comment_not_found":          "Comment not found.",
+	"revision_not_found":         "Revision not found.",
+	"precondition_failed":        "This project was changed since you read it. Reload it and try again.",
+	"precondition_required":      "The If-Match header is required to change this project.",
+	"user_not_found":             "User not found.",
+	"image_not_found":            "Image not found.",
+	"media_not_found":            "Media not found.",
+	"image_required":             "Image is required.",
+	"unsupported_image_format":   "Unsupported image format.",
+	"invalid_image":              "Invalid image.",
+	"invalid_image_order":        "Image order must list every image of the project once.",
+	"unknown_role":               "Unknown role.",
+	"quota_exceeded":             "Upload quota exceeded.",
+	"quota_exceeded_bytes":       "Upload quota exceeded: %d of %d bytes used.",
+	"quota_exceeded_files":       "Upload quota exceeded: %d of %d files used.",
+	"too_many_requests":          "Too many requests.",
+	"internal_error":             "Internal server error.",
+	"password_too_short":         "Password must be at least 8 characters long.",
+	"password_missing_uppercase": "Password must contain at least one uppercase letter.",
+	"password_missing_lowercase": "Password must contain at least one lowercase letter.",
+	"password_missing_number":    "Password must contain at least one number.",
+	"password_missing_special":   "Password must contain at least one special character.",
+	"required":                   "This field is required.",
+	"email":                      "This field must be a valid email address.",
+	"min":                        "This field must be at least %s.",
+	"max":                        "This field must be at most %s.",
+	"min_length":                 "This field must contain at least %s characters.",
+	"max_length":                 "This field must contain at most %s characters.",
+	"one_of":                     "This field must be one of: %s.",
+	"invalid":                    "This field is invalid.",
+	"user_created":               "User created successfully.",
+	"project_deleted":            "Project deleted successfully.",
+	"comment_deleted":            "Comment deleted successfully.",
+	"project_liked":              "Project liked successfully.",
+	"project_unliked":            "Project unliked successfully.",
+	"image_deleted":              "Image deleted successfully.",
+}
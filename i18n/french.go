@@ -0,0 +1,53 @@
+package i18n
+
+var french = map[string]string{
+	"invalid_data":               "Données invalides.",
+	"invalid_id":                 "Identifiant invalide.",
+	"no_data_to_update":          "Aucune donnée à modifier.",
+	"invalid_credentials":        "Email ou mot de passe invalide.",
+	"email_already_used":         "Cet email est déjà utilisé.",
+	"unauthorized":               "Authentification requise.",
+	"invalid_token":              "Token invalide ou expiré.",
+	"forbidden":                  "Accès refusé.",
+	"route_not_found":            "Route introuvable.",
+	"project_not_found":          "Projet introuvable.",
+	"project_archived":           "Ce projet est archivé et ne peut plus être modifié.",
+	"invalid_status_change":      "Ce changement d'état n'est pas permis pour un projet à l'état « %s ».",
+	"restore_expired":            "Ce projet a été supprimé depuis trop longtemps pour être restauré.",
+	"comment_not_found":          "Commentaire introuvable.",
+	"revision_not_found":         "Révision introuvable.",
+	"precondition_failed":        "Ce projet a été modifié depuis que vous l'avez lu. Rechargez-le puis réessayez.",
+	"precondition_required":      "L'en-tête If-Match est obligatoire pour modifier ce projet.",
+	"user_not_found":             "Utilisateur introuvable.",
+	"image_not_found":            "Image introuvable.",
+	"media_not_found":            "Fichier introuvable.",
+	"image_required":             "L'image est obligatoire.",
+	"unsupported_image_format":   "Format d'image non pris en charge.",
+	"invalid_image":              "Image invalide.",
+	"invalid_image_order":        "L'ordre doit lister chaque image du projet une seule fois.",
+	"unknown_role":               "Rôle inconnu.",
+	"quota_exceeded":             "Quota de stockage dépassé.",
+	"quota_exceeded_bytes":       "Quota de stockage dépassé : %d octets sur %d utilisés.",
+	"quota_exceeded_files":       "Quota de stockage dépassé : %d fichiers sur %d utilisés.",
+	"too_many_requests":          "Trop de requêtes.",
+	"internal_error":             "Erreur interne du serveur.",
+	"password_too_short":         "Le mot de passe doit contenir au moins 8 caractères.",
+	"password_missing_uppercase": "Le mot de passe doit contenir au moins une lettre majuscule.",
+	"password_missing_lowercase": "Le mot de passe doit contenir au moins une lettre minuscule.",
+	"password_missing_number":    "Le mot de passe doit contenir au moins un chiffre.",
+	"password_missing_special":   "Le mot de passe doit contenir au moins un caractère spécial.",
+	"required":                   "Ce champ est obligatoire.",
+	"email":                      "Ce champ doit être une adresse email valide.",
+	"min":                        "Ce champ doit valoir au moins %s.",
+	"max":                        "Ce champ doit valoir au plus %s.",
+	"min_length":                 "Ce champ doit contenir au moins %s caractères.",
+	"max_length":                 "Ce champ doit contenir au plus %s caractères.",
+	"one_of":                     "Ce champ doit prendre l'une des valeurs suivantes : %s.",
+	"invalid":                    "Ce champ est invalide.",
+	"user_created":               "Utilisateur créé avec succès.",
+	"project_deleted":            "Projet supprimé avec succès.",
+	"comment_deleted":            "Commentaire supprimé avec succès.",
+	"project_liked":              "Like ajouté avec succès.",
+	"project_unliked":            "Like retiré avec succès.",
+	"image_deleted":              "Image supprimée avec succès.",
+}
@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+const (
+	English = "en"
+	French  = "fr"
+)
+
+var Supported = []string{English, French}
+
+var catalogs = map[string]map[string]string{
+	English: english,
+	French:  french,
+}
+
+const contextKey = "language"
+
+// Negotiator picks the supported language best matching an Accept-Language header.
+type Negotiator struct {
+	languages []string
+	matcher   language.Matcher
+}
+
+// NewNegotiator creates a negotiator falling back to the given language when none of the accepted ones is supported.
+func NewNegotiator(fallback string) Negotiator {
+	languages := []string{fallback}
+	for _, supported := range Supported {
+		if supported != fallback {
+			languages = append(languages, supported)
+		}
+	}
+
+	tags := make([]language.Tag, 0, len(languages))
+	for _, name := range languages {
+		tags = append(tags, language.Make(name))
+	}
+
+	return Negotiator{languages: languages, matcher: language.NewMatcher(tags)}
+}
+
+func (negotiator Negotiator) Negotiate(acceptLanguage string) string {
+	_, index := language.MatchStrings(negotiator.matcher, acceptLanguage)
+
+	return negotiator.languages[index]
+}
+
+func Set(context *gin.Context, language string) {
+	context.Set(contextKey, language)
+}
+
+// FromContext returns the language negotiated for the request, or English when none was.
+func FromContext(context *gin.Context) string {
+	if language := context.GetString(contextKey); language != "" {
+		return language
+	}
+
+	return English
+}
+
+// Translate returns the message of the key in the language, falling back to English, then to the key itself.
+func Translate(language string, key string, args ...any) string {
+	message, ok := catalogs[language][key]
+	if !ok {
+		message, ok = english[key]
+	}
+
+	if !ok {
+		return key
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+
+	return fmt.Sprintf(message, args...)
+}
+
+// T translates the key in the language of the request.
+func T(context *gin.Context, key string, args ...any) string {
+	return Translate(FromContext(context), key, args...)
+}
@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"partage-projets/services"
+	"time"
+)
+
+type TrashPurgeReport struct {
+	Projects []uint `json:"projects"`
+	Comments int64  `json:"comments"`
+}
+
+// PurgeTrash removes for good the projects and comments deleted for longer than the trash retention.
+func PurgeTrash(ctx context.Context, projects *services.ProjectService, comments *services.CommentService) (*TrashPurgeReport, error) {
+	purged, err := projects.PurgeExpired(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TrashPurgeReport{Projects: purged}
+
+	report.Comments, err = comments.PurgeExpired(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// StartTrashPurger purges the trash at every interval until the context is cancelled.
+// The returned channel is closed once the purger has stopped, after any purge in progress.
+func StartTrashPurger(ctx context.Context, projects *services.ProjectService, comments *services.CommentService, interval time.Duration) <-chan struct{} {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				report, err := PurgeTrash(ctx, projects, comments)
+				if err != nil {
+					slog.Error("Unable to purge trash.", slog.Any("error", err))
+
+					continue
+				}
+
+				slog.Info("Trash purge done.",
+					slog.Int("projects", len(report.Projects)),
+					slog.Int64("comments", report.Comments),
+				)
+			}
+		}
+	}()
+
+	return done
+}
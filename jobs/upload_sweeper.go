@@ -0,0 +1,115 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"partage-projets/repositories"
+	"path/filepath"
+	"time"
+)
+
+type UploadSweepReport struct {
+	DryRun  bool     `json:"dry_run"`
+	Scanned int      `json:"scanned"`
+	Orphans []string `json:"orphans"`
+	Removed []string `json:"removed"`
+	Recent  []string `json:"recent"`
+}
+
+// SweepUploads removes the files of the directory that are no longer referenced in the database.
+// Files younger than the grace period are kept, since their upload may not be committed yet.
+func SweepUploads(ctx context.Context, uploads repositories.UploadRepository, directory string, dryRun bool, gracePeriod time.Duration) (*UploadSweepReport, error) {
+	report := &UploadSweepReport{DryRun: dryRun, Orphans: []string{}, Removed: []string{}, Recent: []string{}}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return report, nil
+		}
+
+		return nil, err
+	}
+
+	referenced, err := uploads.ReferencedPaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+		report.Scanned++
+
+		if referenced[name] {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		if time.Since(info.ModTime()) < gracePeriod {
+			report.Recent = append(report.Recent, name)
+
+			continue
+		}
+
+		report.Orphans = append(report.Orphans, name)
+
+		if dryRun {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(directory, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		if err := uploads.DeleteByPath(ctx, name); err != nil {
+			return nil, err
+		}
+
+		report.Removed = append(report.Removed, name)
+	}
+
+	return report, nil
+}
+
+// StartUploadSweeper sweeps the uploads at every interval until the context is cancelled.
+// The returned channel is closed once the sweeper has stopped, after any sweep in progress.
+func StartUploadSweeper(ctx context.Context, uploads repositories.UploadRepository, directory string, interval time.Duration, gracePeriod time.Duration) <-chan struct{} {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				report, err := SweepUploads(ctx, uploads, directory, false, gracePeriod)
+				if err != nil {
+					slog.Error("Unable to sweep uploads.", slog.Any("error", err))
+
+					continue
+				}
+
+				slog.Info("Upload sweep done.",
+					slog.Int("scanned", report.Scanned),
+					slog.Int("removed", len(report.Removed)),
+					slog.Int("recent", len(report.Recent)),
+				)
+			}
+		}
+	}()
+
+	return done
+}
@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// GormLogger writes the queries to slog: at debug level, or warn when slower than the threshold, or error when failing.
+type GormLogger struct {
+	SlowThreshold time.Duration
+}
+
+func NewGormLogger() GormLogger {
+	return GormLogger{SlowThreshold: 200 * time.Millisecond}
+}
+
+func (gormLogger GormLogger) LogMode(logger.LogLevel) logger.Interface {
+	// The level is driven by the slog handler.
+	return gormLogger
+}
+
+func (gormLogger GormLogger) Info(ctx context.Context, message string, data ...interface{}) {
+	slog.InfoContext(ctx, fmt.Sprintf(message, data...))
+}
+
+func (gormLogger GormLogger) Warn(ctx context.Context, message string, data ...interface{}) {
+	slog.WarnContext(ctx, fmt.Sprintf(message, data...))
+}
+
+func (gormLogger GormLogger) Error(ctx context.Context, message string, data ...interface{}) {
+	slog.ErrorContext(ctx, fmt.Sprintf(message, data...))
+}
+
+func (gormLogger GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	elapsed := time.Since(begin)
+
+	level := slog.LevelDebug
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		level = slog.LevelError
+	case gormLogger.SlowThreshold > 0 && elapsed > gormLogger.SlowThreshold:
+		level = slog.LevelWarn
+	}
+
+	if !slog.Default().Enabled(ctx, level) {
+		return
+	}
+
+	sql, rows := fc()
+
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Duration("duration", elapsed),
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	slog.LogAttrs(ctx, level, "query", attrs...)
+}
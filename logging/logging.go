@@ -0,0 +1,79 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type requestInfoKey struct{}
+
+// RequestInfo identifies the request a log line belongs to. It is stored as a pointer in the request context,
+// so that the user ID can be filled in once the request is authenticated.
+type RequestInfo struct {
+	RequestID string
+	UserID    uint
+}
+
+func WithRequestInfo(ctx context.Context, info *RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+func RequestInfoFrom(ctx context.Context) *RequestInfo {
+	if ctx == nil {
+		return nil
+	}
+
+	info, _ := ctx.Value(requestInfoKey{}).(*RequestInfo)
+
+	return info
+}
+
+// ContextHandler adds the request ID, user ID and trace of the context to every record.
+type ContextHandler struct {
+	slog.Handler
+}
+
+func (handler ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if info := RequestInfoFrom(ctx); info != nil {
+		record.AddAttrs(slog.String("request_id", info.RequestID))
+
+		if info.UserID != 0 {
+			record.AddAttrs(slog.Uint64("user_id", uint64(info.UserID)))
+		}
+	}
+
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		record.AddAttrs(slog.String("trace_id", span.TraceID().String()), slog.String("span_id", span.SpanID().String()))
+	}
+
+	return handler.Handler.Handle(ctx, record)
+}
+
+func (handler ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return ContextHandler{handler.Handler.WithAttrs(attrs)}
+}
+
+func (handler ContextHandler) WithGroup(name string) slog.Handler {
+	return ContextHandler{handler.Handler.WithGroup(name)}
+}
+
+func ParseLevel(value string) (slog.Level, error) {
+	var level slog.Level
+
+	err := level.UnmarshalText([]byte(strings.ToUpper(value)))
+
+	return level, err
+}
+
+// Setup makes a JSON logger writing to the writer the default one, including for the standard log package.
+func Setup(writer io.Writer, level slog.Level) *slog.Logger {
+	logger := slog.New(ContextHandler{slog.NewJSONHandler(writer, &slog.HandlerOptions{Level: level})})
+
+	slog.SetDefault(logger)
+
+	return logger
+}
@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds every collector exposed on /metrics. A dedicated registry is used rather than the global one,
+// so that the database collector can be replaced when the connection is reopened.
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+var (
+	HTTPRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Number of HTTP requests, by method, route template and status.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Duration of HTTP requests, by method, route template and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	RateLimitRejections = factory.NewCounter(prometheus.CounterOpts{
+		Name: "http_rate_limit_rejections_total",
+		Help: "Number of requests rejected by the rate limiter.",
+	})
+
+	UploadBytes = factory.NewCounter(prometheus.CounterOpts{
+		Name: "upload_bytes_total",
+		Help: "Number of bytes stored by image uploads, after resizing.",
+	})
+
+	Registrations = factory.NewCounter(prometheus.CounterOpts{
+		Name: "user_registrations_total",
+		Help: "Number of registered users.",
+	})
+
+	ProjectsCreated = factory.NewCounter(prometheus.CounterOpts{
+		Name: "projects_created_total",
+		Help: "Number of created projects.",
+	})
+
+	ProjectLikes = factory.NewCounterVec(prometheus.CounterOpts{
+		Name: "project_likes_total",
+		Help: "Number of likes given or withdrawn, by action.",
+	}, []string{"action"})
+
+	CommentsCreated = factory.NewCounter(prometheus.CounterOpts{
+		Name: "comments_created_total",
+		Help: "Number of created comments.",
+	})
+)
+
+var database prometheus.Collector
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// RegisterDatabase exposes the connection pool statistics of db, replacing the previously registered pool.
+func RegisterDatabase(db *sql.DB) error {
+	if database != nil {
+		Registry.Unregister(database)
+	}
+
+	database = collectors.NewDBStatsCollector(db, "default")
+
+	return Registry.Register(database)
+}
+
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
@@ -2,66 +2,112 @@ package middlewares
 
 import (
 	"net/http"
-	"os"
+	"partage-projets/logging"
+	"partage-projets/problem"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func Authentication() gin.HandlerFunc {
+func Authentication(secret string) gin.HandlerFunc {
 	return func(context *gin.Context) {
 		authHeader := context.GetHeader("Authorization")
 
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-			context.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized."})
+			problem.Abort(context, http.StatusUnauthorized, problem.Unauthorized)
 
 			return
 		}
 
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrTokenMalformed
-			}
+		if authenticate(context, secret, strings.TrimPrefix(authHeader, "Bearer ")) {
+			context.Next()
+		}
+	}
+}
 
-			return []byte(os.Getenv("JWT_SECRET")), nil
-		})
+// OptionalAuthentication lets anonymous requests through, but still rejects an invalid token.
+func OptionalAuthentication(secret string) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		authHeader := context.GetHeader("Authorization")
 
-		if err != nil || !token.Valid {
-			context.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token."})
+		if authHeader == "" {
+			context.Next()
 
 			return
 		}
 
-		claim, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			context.JSON(http.StatusUnauthorized, gin.H{"error": "Unable to read token."})
+		if !strings.HasPrefix(authHeader, "Bearer ") {
+			problem.Abort(context, http.StatusUnauthorized, problem.InvalidToken)
 
 			return
 		}
 
-		userID := int(claim["UserID"].(float64))
+		if authenticate(context, secret, strings.TrimPrefix(authHeader, "Bearer ")) {
+			context.Next()
+		}
+	}
+}
+
+// authenticate stores the user of a valid token in the context, or aborts the request.
+func authenticate(context *gin.Context, secret string, tokenString string) bool {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrTokenMalformed
+		}
+
+		return []byte(secret), nil
+	})
 
-		context.Set("userID", userID)
+	if err != nil || !token.Valid {
+		problem.Abort(context, http.StatusUnauthorized, problem.InvalidToken)
+
+		return false
+	}
+
+	claim, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		problem.Abort(context, http.StatusUnauthorized, problem.InvalidToken)
 
-		context.Next()
+		return false
 	}
+
+	rawUserID, ok := claim["UserID"].(float64)
+	if !ok {
+		problem.Abort(context, http.StatusUnauthorized, problem.InvalidToken)
+
+		return false
+	}
+
+	userID := int(rawUserID)
+
+	context.Set("userID", userID)
+
+	if info := logging.RequestInfoFrom(context.Request.Context()); info != nil {
+		info.UserID = uint(userID)
+	}
+
+	return true
 }
 
 func GetUserId(context *gin.Context) *uint {
+	userID := GetOptionalUserId(context)
+	if userID == nil {
+		problem.Abort(context, http.StatusUnauthorized, problem.Unauthorized)
+	}
+
+	return userID
+}
+
+// GetOptionalUserId returns the authenticated user, or nil for an anonymous request.
+func GetOptionalUserId(context *gin.Context) *uint {
 	userID, ok := context.Get("userID")
 	if !ok {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID."})
-
 		return nil
 	}
 
 	userIDInt, ok := userID.(int)
 	if !ok {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID."})
-
 		return nil
 	}
 
@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecated flags the responses of legacy routes with the Deprecation (RFC 9745) and Sunset (RFC 8594) headers,
+// and links to the same path under the prefix of the version replacing them.
+func Deprecated(deprecation time.Time, sunset time.Time, successorPrefix string) gin.HandlerFunc {
+	deprecationHeader := "@" + strconv.FormatInt(deprecation.Unix(), 10)
+	sunsetHeader := sunset.UTC().Format(http.TimeFormat)
+
+	return func(context *gin.Context) {
+		context.Header("Deprecation", deprecationHeader)
+		context.Header("Sunset", sunsetHeader)
+		context.Header("Link", "<"+successorPrefix+context.Request.URL.Path+`>; rel="successor-version"`)
+
+		context.Next()
+	}
+}
@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"partage-projets/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Language picks the language of the messages from the Accept-Language header, falling back to the default one.
+func Language(defaultLanguage string) gin.HandlerFunc {
+	negotiator := i18n.NewNegotiator(defaultLanguage)
+
+	return func(context *gin.Context) {
+		language := negotiator.Negotiate(context.GetHeader("Accept-Language"))
+
+		i18n.Set(context, language)
+
+		context.Header("Content-Language", language)
+		context.Writer.Header().Add("Vary", "Accept-Language")
+
+		context.Next()
+	}
+}
@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"log/slog"
+	"net/http"
+	"partage-projets/problem"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Logger() gin.HandlerFunc {
+	return func(context *gin.Context) {
+		start := time.Now()
+
+		context.Next()
+
+		status := context.Writer.Status()
+
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		} else if status >= http.StatusBadRequest {
+			level = slog.LevelWarn
+		}
+
+		route := context.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		slog.LogAttrs(context.Request.Context(), level, "request",
+			slog.String("method", context.Request.Method),
+			slog.String("route", route),
+			slog.String("path", context.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(start)),
+			slog.Int("size", context.Writer.Size()),
+			slog.String("client_ip", context.ClientIP()),
+		)
+	}
+}
+
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(context *gin.Context, err any) {
+		slog.ErrorContext(context.Request.Context(), "panic", slog.Any("error", err))
+
+		problem.Abort(context, http.StatusInternalServerError, problem.InternalError)
+	})
+}
@@ -0,0 +1,51 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"partage-projets/metrics"
+	"partage-projets/problem"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Metrics() gin.HandlerFunc {
+	return func(context *gin.Context) {
+		start := time.Now()
+
+		context.Next()
+
+		// The route template is used rather than the path, to keep the number of series bounded.
+		route := context.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		labels := []string{context.Request.Method, route, strconv.Itoa(context.Writer.Status())}
+
+		metrics.HTTPRequests.WithLabelValues(labels...).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+	}
+}
+
+// RequireBearerToken rejects requests that don't carry the given bearer token. An empty token disables the check.
+func RequireBearerToken(token string) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		if token == "" {
+			context.Next()
+
+			return
+		}
+
+		expected := []byte("Bearer " + token)
+		if subtle.ConstantTimeCompare([]byte(context.GetHeader("Authorization")), expected) != 1 {
+			problem.Abort(context, http.StatusUnauthorized, problem.InvalidToken)
+
+			return
+		}
+
+		context.Next()
+	}
+}
@@ -0,0 +1,22 @@
+package middlewares
+
+import (
+	"net/http"
+	"partage-projets/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireIfMatch rejects the requests without an If-Match header with 428, so that clients can't overwrite
+// changes they haven't seen.
+func RequireIfMatch() gin.HandlerFunc {
+	return func(context *gin.Context) {
+		if context.GetHeader("If-Match") == "" {
+			problem.Abort(context, http.StatusPreconditionRequired, problem.PreconditionRequired)
+
+			return
+		}
+
+		context.Next()
+	}
+}
@@ -0,0 +1,40 @@
+package middlewares
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"partage-projets/logging"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+func RequestID() gin.HandlerFunc {
+	return func(context *gin.Context) {
+		requestID := context.GetHeader(RequestIDHeader)
+
+		// Incoming IDs end up in the logs, so anything unusual is replaced.
+		if !validRequestID.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+
+		context.Set("requestID", requestID)
+		context.Header(RequestIDHeader, requestID)
+
+		info := &logging.RequestInfo{RequestID: requestID}
+		context.Request = context.Request.WithContext(logging.WithRequestInfo(context.Request.Context(), info))
+
+		context.Next()
+	}
+}
+
+func newRequestID() string {
+	bytes := make([]byte, 16)
+	_, _ = rand.Read(bytes)
+
+	return hex.EncodeToString(bytes)
+}
@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	"net/http"
+	"partage-projets/problem"
+	"partage-projets/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RequireRole(users repositories.UserRepository, role string) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		userId := GetUserId(context)
+		if userId == nil {
+			context.Abort()
+
+			return
+		}
+
+		user, err := users.Find(context.Request.Context(), *userId)
+		if err != nil || user.Role != role {
+			problem.Abort(context, http.StatusForbidden, problem.Forbidden)
+
+			return
+		}
+
+		context.Next()
+	}
+}
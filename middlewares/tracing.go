@@ -0,0 +1,13 @@
+package middlewares
+
+import (
+	"partage-projets/tracing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// Tracing starts a span for each request, continuing the trace from the incoming traceparent header if any.
+func Tracing() gin.HandlerFunc {
+	return otelgin.Middleware(tracing.ServiceName)
+}
@@ -0,0 +1,301 @@
+package migrations
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+type Migration struct {
+	Version string
+	Name    string
+	Up      string
+	Down    string
+}
+
+type SchemaMigration struct {
+	Version   string `gorm:"primaryKey"`
+	AppliedAt time.Time
+}
+
+type Status struct {
+	Version   string     `json:"version"`
+	Name      string     `json:"name"`
+	AppliedAt *time.Time `json:"applied_at"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Load reads the embedded migrations of a dialect, named "<version>_<name>.up.sql" and "<version>_<name>.down.sql".
+func Load(dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for dialect %s: %w", dialect, err)
+	}
+
+	migrations := make(map[string]*Migration)
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		base, direction, found := strings.Cut(strings.TrimSuffix(name, ".sql"), ".")
+		if !found || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("invalid migration file name: %s", name)
+		}
+
+		version, description, _ := strings.Cut(base, "_")
+
+		content, err := files.ReadFile(path.Join(dialect, name))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := migrations[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: description}
+			migrations[version] = migration
+		}
+
+		if direction == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	sorted := make([]Migration, 0, len(migrations))
+	for _, migration := range migrations {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %s must have both an up and a down file", migration.Version)
+		}
+
+		sorted = append(sorted, *migration)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version < sorted[j].Version
+	})
+
+	return sorted, nil
+}
+
+func Up(db *gorm.DB) ([]Migration, error) {
+	migrations, applied, err := prepare(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := execute(tx, migration.Up); err != nil {
+				return err
+			}
+
+			return tx.Create(&SchemaMigration{Version: migration.Version, AppliedAt: time.Now()}).Error
+		})
+
+		if err != nil {
+			return done, fmt.Errorf("migration %s_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+// Down rolls back the given number of applied migrations, most recent first.
+func Down(db *gorm.DB, steps int) ([]Migration, error) {
+	migrations, applied, err := prepare(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+
+	for index := len(migrations) - 1; index >= 0 && len(done) < steps; index-- {
+		migration := migrations[index]
+
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := execute(tx, migration.Down); err != nil {
+				return err
+			}
+
+			return tx.Delete(&SchemaMigration{Version: migration.Version}).Error
+		})
+
+		if err != nil {
+			return done, fmt.Errorf("migration %s_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		done = append(done, migration)
+	}
+
+	return done, nil
+}
+
+func List(db *gorm.DB) ([]Status, error) {
+	migrations, applied, err := prepare(db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+
+	for _, migration := range migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Pending returns the number of migrations that have not been applied yet.
+func Pending(db *gorm.DB) (int, error) {
+	statuses, err := List(db)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+
+	return pending, nil
+}
+
+// Inspect returns the version of the last applied migration and the number of pending ones, reading the applied
+// versions once without changing the schema, so that it can be called by frequent checks like the readiness probe.
+func Inspect(db *gorm.DB) (string, int, error) {
+	migrations, err := Load(db.Dialector.Name())
+	if err != nil {
+		return "", 0, err
+	}
+
+	applied, err := readApplied(db, migrations)
+	if err != nil {
+		return "", 0, err
+	}
+
+	current, pending := "", 0
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; ok {
+			current = migration.Version
+		} else {
+			pending++
+		}
+	}
+
+	return current, pending, nil
+}
+
+func prepare(db *gorm.DB) ([]Migration, map[string]time.Time, error) {
+	migrations, err := Load(db.Dialector.Name())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := db.AutoMigrate(&SchemaMigration{}); err != nil {
+		return nil, nil, err
+	}
+
+	applied, err := readApplied(db, migrations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return migrations, applied, nil
+}
+
+// readApplied returns when each migration was applied, by version, refusing the versions unknown to the binary.
+func readApplied(db *gorm.DB, migrations []Migration) (map[string]time.Time, error) {
+	var rows []SchemaMigration
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[string]time.Time)
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+	}
+
+	for version := range applied {
+		known := slices.ContainsFunc(migrations, func(migration Migration) bool {
+			return migration.Version == version
+		})
+
+		if !known {
+			return nil, errors.New("database has unknown migration " + version + ", is the binary outdated?")
+		}
+	}
+
+	return applied, nil
+}
+
+// execute runs the statements one by one, as the postgres driver refuses several statements in a single query.
+// SQLite scripts are run at once, since their triggers contain semicolons.
+func execute(tx *gorm.DB, script string) error {
+	if tx.Dialector.Name() == "sqlite" {
+		return tx.Exec(script).Error
+	}
+
+	for _, statement := range strings.Split(script, ";") {
+		if strings.TrimSpace(statement) == "" {
+			continue
+		}
+
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Current returns the version of the last applied migration, or an empty string when none is applied.
+func Current(db *gorm.DB) (string, error) {
+	statuses, err := List(db)
+	if err != nil {
+		return "", err
+	}
+
+	current := ""
+	for _, status := range statuses {
+		if status.AppliedAt != nil {
+			current = status.Version
+		}
+	}
+
+	return current, nil
+}
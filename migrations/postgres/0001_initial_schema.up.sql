@@ -0,0 +1,71 @@
+CREATE TABLE IF NOT EXISTS projects (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	name text,
+	description text,
+	image text,
+	skills json
+);
+
+CREATE TABLE IF NOT EXISTS users (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	email text,
+	password text,
+	role text DEFAULT 'user',
+	CONSTRAINT uni_users_email UNIQUE (email)
+);
+
+CREATE TABLE IF NOT EXISTS project_likes (
+	user_id bigint,
+	project_id bigint,
+	PRIMARY KEY (user_id, project_id),
+	CONSTRAINT fk_project_likes_user FOREIGN KEY (user_id) REFERENCES users (id),
+	CONSTRAINT fk_project_likes_project FOREIGN KEY (project_id) REFERENCES projects (id)
+);
+
+CREATE TABLE IF NOT EXISTS comments (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	project_id bigint,
+	user_id bigint,
+	content text,
+	CONSTRAINT fk_projects_comments FOREIGN KEY (project_id) REFERENCES projects (id),
+	CONSTRAINT fk_users_comments FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+CREATE TABLE IF NOT EXISTS project_images (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	project_id bigint,
+	path text,
+	caption text,
+	alt_text text,
+	position bigint,
+	cover boolean,
+	CONSTRAINT fk_projects_images FOREIGN KEY (project_id) REFERENCES projects (id)
+);
+
+CREATE TABLE IF NOT EXISTS uploads (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	user_id bigint,
+	path text,
+	size bigint
+);
+
+CREATE INDEX IF NOT EXISTS idx_uploads_user_id ON uploads (user_id);
+
+CREATE INDEX IF NOT EXISTS idx_uploads_path ON uploads (path);
+
+CREATE TABLE IF NOT EXISTS role_quota (
+	role text PRIMARY KEY,
+	created_at timestamptz,
+	updated_at timestamptz,
+	max_bytes bigint,
+	max_files bigint
+);
@@ -0,0 +1,13 @@
+ALTER TABLE projects ADD COLUMN owner_id bigint;
+ALTER TABLE projects ADD COLUMN visibility text NOT NULL DEFAULT 'public';
+ALTER TABLE projects ADD CONSTRAINT fk_projects_owner FOREIGN KEY (owner_id) REFERENCES users (id);
+
+CREATE INDEX IF NOT EXISTS idx_projects_owner_id ON projects (owner_id);
+
+CREATE TABLE IF NOT EXISTS project_collaborators (
+	project_id bigint,
+	user_id bigint,
+	PRIMARY KEY (project_id, user_id),
+	CONSTRAINT fk_project_collaborators_project FOREIGN KEY (project_id) REFERENCES projects (id),
+	CONSTRAINT fk_project_collaborators_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
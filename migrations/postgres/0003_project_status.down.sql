@@ -0,0 +1,2 @@
+ALTER TABLE projects DROP COLUMN IF EXISTS published_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS status;
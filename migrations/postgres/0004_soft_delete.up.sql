@@ -0,0 +1,7 @@
+ALTER TABLE users ADD COLUMN deleted_at timestamptz;
+ALTER TABLE projects ADD COLUMN deleted_at timestamptz;
+ALTER TABLE comments ADD COLUMN deleted_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_projects_deleted_at ON projects (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_comments_deleted_at ON comments (deleted_at);
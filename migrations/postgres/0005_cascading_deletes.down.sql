@@ -0,0 +1,20 @@
+ALTER TABLE projects DROP CONSTRAINT IF EXISTS fk_projects_owner;
+ALTER TABLE projects ADD CONSTRAINT fk_projects_owner FOREIGN KEY (owner_id) REFERENCES users (id);
+
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS fk_users_comments;
+ALTER TABLE comments ADD CONSTRAINT fk_users_comments FOREIGN KEY (user_id) REFERENCES users (id);
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS fk_projects_comments;
+ALTER TABLE comments ADD CONSTRAINT fk_projects_comments FOREIGN KEY (project_id) REFERENCES projects (id);
+
+ALTER TABLE project_images DROP CONSTRAINT IF EXISTS fk_projects_images;
+ALTER TABLE project_images ADD CONSTRAINT fk_projects_images FOREIGN KEY (project_id) REFERENCES projects (id);
+
+ALTER TABLE project_collaborators DROP CONSTRAINT IF EXISTS fk_project_collaborators_project;
+ALTER TABLE project_collaborators ADD CONSTRAINT fk_project_collaborators_project FOREIGN KEY (project_id) REFERENCES projects (id);
+ALTER TABLE project_collaborators DROP CONSTRAINT IF EXISTS fk_project_collaborators_user;
+ALTER TABLE project_collaborators ADD CONSTRAINT fk_project_collaborators_user FOREIGN KEY (user_id) REFERENCES users (id);
+
+ALTER TABLE project_likes DROP CONSTRAINT IF EXISTS fk_project_likes_project;
+ALTER TABLE project_likes ADD CONSTRAINT fk_project_likes_project FOREIGN KEY (project_id) REFERENCES projects (id);
+ALTER TABLE project_likes DROP CONSTRAINT IF EXISTS fk_project_likes_user;
+ALTER TABLE project_likes ADD CONSTRAINT fk_project_likes_user FOREIGN KEY (user_id) REFERENCES users (id);
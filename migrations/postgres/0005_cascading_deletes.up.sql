@@ -0,0 +1,28 @@
+-- Rows left behind by earlier deletions would prevent the constraints from being validated.
+DELETE FROM project_likes WHERE project_id NOT IN (SELECT id FROM projects) OR user_id NOT IN (SELECT id FROM users);
+DELETE FROM project_collaborators WHERE project_id NOT IN (SELECT id FROM projects) OR user_id NOT IN (SELECT id FROM users);
+DELETE FROM project_images WHERE project_id NOT IN (SELECT id FROM projects);
+DELETE FROM comments WHERE project_id NOT IN (SELECT id FROM projects) OR user_id NOT IN (SELECT id FROM users);
+UPDATE projects SET owner_id = NULL WHERE owner_id NOT IN (SELECT id FROM users);
+
+ALTER TABLE project_likes DROP CONSTRAINT IF EXISTS fk_project_likes_user;
+ALTER TABLE project_likes ADD CONSTRAINT fk_project_likes_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+ALTER TABLE project_likes DROP CONSTRAINT IF EXISTS fk_project_likes_project;
+ALTER TABLE project_likes ADD CONSTRAINT fk_project_likes_project FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE;
+
+ALTER TABLE project_collaborators DROP CONSTRAINT IF EXISTS fk_project_collaborators_user;
+ALTER TABLE project_collaborators ADD CONSTRAINT fk_project_collaborators_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+ALTER TABLE project_collaborators DROP CONSTRAINT IF EXISTS fk_project_collaborators_project;
+ALTER TABLE project_collaborators ADD CONSTRAINT fk_project_collaborators_project FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE;
+
+ALTER TABLE project_images DROP CONSTRAINT IF EXISTS fk_projects_images;
+ALTER TABLE project_images ADD CONSTRAINT fk_projects_images FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE;
+
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS fk_projects_comments;
+ALTER TABLE comments ADD CONSTRAINT fk_projects_comments FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE;
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS fk_users_comments;
+ALTER TABLE comments ADD CONSTRAINT fk_users_comments FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+
+-- The projects of a deleted user are kept, without an owner.
+ALTER TABLE projects DROP CONSTRAINT IF EXISTS fk_projects_owner;
+ALTER TABLE projects ADD CONSTRAINT fk_projects_owner FOREIGN KEY (owner_id) REFERENCES users (id) ON DELETE SET NULL;
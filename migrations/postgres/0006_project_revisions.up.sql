@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS project_revisions (
+	id bigserial PRIMARY KEY,
+	created_at timestamptz,
+	project_id bigint,
+	user_id bigint,
+	name text,
+	description text,
+	image text,
+	skills json,
+	visibility text,
+	changes json,
+	CONSTRAINT fk_projects_revisions FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE,
+	CONSTRAINT fk_users_revisions FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_revisions_project_id ON project_revisions (project_id);
+
+-- The current state of existing projects is their first revision, so that they can be restored to it.
+INSERT INTO project_revisions (created_at, project_id, user_id, name, description, image, skills, visibility, changes)
+SELECT updated_at, id, owner_id, name, description, image, skills, visibility, '[]' FROM projects;
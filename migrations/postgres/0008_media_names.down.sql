@@ -0,0 +1,5 @@
+-- The upload directory isn't known to the database, so the default one is assumed.
+UPDATE projects SET image = 'uploads/' || image WHERE image <> '';
+UPDATE project_images SET path = 'uploads/' || path WHERE path <> '';
+UPDATE project_revisions SET image = 'uploads/' || image WHERE image <> '';
+UPDATE uploads SET path = 'uploads/' || path WHERE path <> '';
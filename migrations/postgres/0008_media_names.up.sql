@@ -0,0 +1,5 @@
+-- Stored files were referred to by their path, they are now referred to by their name in the upload directory.
+UPDATE projects SET image = regexp_replace(image, '^.*/', '') WHERE image LIKE '%/%';
+UPDATE project_images SET path = regexp_replace(path, '^.*/', '') WHERE path LIKE '%/%';
+UPDATE project_revisions SET image = regexp_replace(image, '^.*/', '') WHERE image LIKE '%/%';
+UPDATE uploads SET path = regexp_replace(path, '^.*/', '') WHERE path LIKE '%/%';
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS role_quota;
+
+DROP TABLE IF EXISTS uploads;
+
+DROP TABLE IF EXISTS project_images;
+
+DROP TABLE IF EXISTS comments;
+
+DROP TABLE IF EXISTS project_likes;
+
+DROP TABLE IF EXISTS users;
+
+DROP TABLE IF EXISTS projects;
@@ -0,0 +1,71 @@
+CREATE TABLE IF NOT EXISTS projects (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	name text,
+	description text,
+	image text,
+	skills json
+);
+
+CREATE TABLE IF NOT EXISTS users (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	email text,
+	password text,
+	role text DEFAULT 'user',
+	CONSTRAINT uni_users_email UNIQUE (email)
+);
+
+CREATE TABLE IF NOT EXISTS project_likes (
+	user_id integer,
+	project_id integer,
+	PRIMARY KEY (user_id, project_id),
+	CONSTRAINT fk_project_likes_user FOREIGN KEY (user_id) REFERENCES users (id),
+	CONSTRAINT fk_project_likes_project FOREIGN KEY (project_id) REFERENCES projects (id)
+);
+
+CREATE TABLE IF NOT EXISTS comments (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	project_id integer,
+	user_id integer,
+	content text,
+	CONSTRAINT fk_projects_comments FOREIGN KEY (project_id) REFERENCES projects (id),
+	CONSTRAINT fk_users_comments FOREIGN KEY (user_id) REFERENCES users (id)
+);
+
+CREATE TABLE IF NOT EXISTS project_images (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	project_id integer,
+	path text,
+	caption text,
+	alt_text text,
+	position integer,
+	cover numeric,
+	CONSTRAINT fk_projects_images FOREIGN KEY (project_id) REFERENCES projects (id)
+);
+
+CREATE TABLE IF NOT EXISTS uploads (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	user_id integer,
+	path text,
+	size integer
+);
+
+CREATE INDEX IF NOT EXISTS idx_uploads_user_id ON uploads (user_id);
+
+CREATE INDEX IF NOT EXISTS idx_uploads_path ON uploads (path);
+
+CREATE TABLE IF NOT EXISTS role_quota (
+	role text PRIMARY KEY,
+	created_at datetime,
+	updated_at datetime,
+	max_bytes integer,
+	max_files integer
+);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS project_collaborators;
+
+DROP INDEX IF EXISTS idx_projects_owner_id;
+
+ALTER TABLE projects DROP COLUMN visibility;
+ALTER TABLE projects DROP COLUMN owner_id;
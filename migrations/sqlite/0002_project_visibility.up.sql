@@ -0,0 +1,13 @@
+-- SQLite cannot drop a column used by a foreign key, so owner_id isn't declared as one.
+ALTER TABLE projects ADD COLUMN owner_id integer;
+ALTER TABLE projects ADD COLUMN visibility text NOT NULL DEFAULT 'public';
+
+CREATE INDEX IF NOT EXISTS idx_projects_owner_id ON projects (owner_id);
+
+CREATE TABLE IF NOT EXISTS project_collaborators (
+	project_id integer,
+	user_id integer,
+	PRIMARY KEY (project_id, user_id),
+	CONSTRAINT fk_project_collaborators_project FOREIGN KEY (project_id) REFERENCES projects (id),
+	CONSTRAINT fk_project_collaborators_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE projects DROP COLUMN published_at;
+ALTER TABLE projects DROP COLUMN status;
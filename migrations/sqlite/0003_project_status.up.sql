@@ -0,0 +1,5 @@
+ALTER TABLE projects ADD COLUMN status text NOT NULL DEFAULT 'draft';
+ALTER TABLE projects ADD COLUMN published_at datetime;
+
+-- Projects created before the workflow existed were visible right away.
+UPDATE projects SET status = 'published', published_at = created_at;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_comments_deleted_at;
+DROP INDEX IF EXISTS idx_projects_deleted_at;
+DROP INDEX IF EXISTS idx_users_deleted_at;
+
+ALTER TABLE comments DROP COLUMN deleted_at;
+ALTER TABLE projects DROP COLUMN deleted_at;
+ALTER TABLE users DROP COLUMN deleted_at;
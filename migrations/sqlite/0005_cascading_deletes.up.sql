@@ -0,0 +1,64 @@
+-- SQLite cannot change the constraints of a table, so the tables referencing projects and users are rebuilt.
+-- Rows left behind by earlier deletions, when the foreign keys weren't enforced, are not copied.
+
+CREATE TABLE project_likes_rebuilt (
+	user_id integer,
+	project_id integer,
+	PRIMARY KEY (user_id, project_id),
+	CONSTRAINT fk_project_likes_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+	CONSTRAINT fk_project_likes_project FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+);
+INSERT INTO project_likes_rebuilt (user_id, project_id) SELECT user_id, project_id FROM project_likes WHERE project_id IN (SELECT id FROM projects) AND user_id IN (SELECT id FROM users);
+DROP TABLE project_likes;
+ALTER TABLE project_likes_rebuilt RENAME TO project_likes;
+
+CREATE TABLE project_collaborators_rebuilt (
+	project_id integer,
+	user_id integer,
+	PRIMARY KEY (project_id, user_id),
+	CONSTRAINT fk_project_collaborators_project FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE,
+	CONSTRAINT fk_project_collaborators_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+INSERT INTO project_collaborators_rebuilt (project_id, user_id) SELECT project_id, user_id FROM project_collaborators WHERE project_id IN (SELECT id FROM projects) AND user_id IN (SELECT id FROM users);
+DROP TABLE project_collaborators;
+ALTER TABLE project_collaborators_rebuilt RENAME TO project_collaborators;
+
+CREATE TABLE project_images_rebuilt (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	project_id integer,
+	path text,
+	caption text,
+	alt_text text,
+	position integer,
+	cover numeric,
+	CONSTRAINT fk_projects_images FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE
+);
+INSERT INTO project_images_rebuilt (id, created_at, updated_at, project_id, path, caption, alt_text, position, cover) SELECT id, created_at, updated_at, project_id, path, caption, alt_text, position, cover FROM project_images WHERE project_id IN (SELECT id FROM projects);
+DROP TABLE project_images;
+ALTER TABLE project_images_rebuilt RENAME TO project_images;
+
+CREATE TABLE comments_rebuilt (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	project_id integer,
+	user_id integer,
+	content text,
+	deleted_at datetime,
+	CONSTRAINT fk_projects_comments FOREIGN KEY (project_id) REFERENCES projects (id) ON DELETE CASCADE,
+	CONSTRAINT fk_users_comments FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+INSERT INTO comments_rebuilt (id, created_at, updated_at, project_id, user_id, content, deleted_at) SELECT id, created_at, updated_at, project_id, user_id, content, deleted_at FROM comments WHERE project_id IN (SELECT id FROM projects) AND user_id IN (SELECT id FROM users);
+DROP TABLE comments;
+ALTER TABLE comments_rebuilt RENAME TO comments;
+CREATE INDEX IF NOT EXISTS idx_comments_deleted_at ON comments (deleted_at);
+
+-- owner_id isn't a foreign key in SQLite, so the projects of a deleted user lose their owner through a trigger.
+UPDATE projects SET owner_id = NULL WHERE owner_id NOT IN (SELECT id FROM users);
+
+CREATE TRIGGER IF NOT EXISTS trg_users_delete_owner AFTER DELETE ON users
+BEGIN
+	UPDATE projects SET owner_id = NULL WHERE owner_id = OLD.id;
+END;
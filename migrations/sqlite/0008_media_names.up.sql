@@ -0,0 +1,6 @@
+-- Stored files were referred to by their path, they are now referred to by their name in the upload directory.
+-- SQLite has no regular expressions: trimming every character but the slashes from the right leaves the directory.
+UPDATE projects SET image = substr(image, length(rtrim(image, replace(image, '/', ''))) + 1) WHERE image LIKE '%/%';
+UPDATE project_images SET path = substr(path, length(rtrim(path, replace(path, '/', ''))) + 1) WHERE path LIKE '%/%';
+UPDATE project_revisions SET image = substr(image, length(rtrim(image, replace(image, '/', ''))) + 1) WHERE image LIKE '%/%';
+UPDATE uploads SET path = substr(path, length(rtrim(path, replace(path, '/', ''))) + 1) WHERE path LIKE '%/%';
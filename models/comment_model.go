@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Comment struct {
 	ID        uint `gorm:"primaryKey"`
@@ -9,4 +13,5 @@ type Comment struct {
 	ProjectID uint `json:"project_id"`
 	UserID    uint
 	Content   string
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
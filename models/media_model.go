@@ -0,0 +1,44 @@
+package models
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+)
+
+const MediaURLPrefix = "/media/"
+
+// Media is the name of a file stored in the upload directory. It is rendered as the URL serving the file,
+// so that the location of the directory never leaks, and read back from either form.
+type Media string
+
+func (media Media) URL() string {
+	if media == "" {
+		return ""
+	}
+
+	return MediaURLPrefix + string(media)
+}
+
+func (media Media) MarshalJSON() ([]byte, error) {
+	return json.Marshal(media.URL())
+}
+
+// UnmarshalJSON keeps the name of the file, whether it is given as a URL or as a path.
+func (media *Media) UnmarshalJSON(data []byte) error {
+	var value string
+
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if value == "" {
+		*media = ""
+
+		return nil
+	}
+
+	*media = Media(path.Base(strings.ReplaceAll(value, `\`, "/")))
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package models
+
+// All lists every model persisted in the database, to check them against the migrations.
+func All() []interface{} {
+	return []interface{}{
+		&Project{},
+		&User{},
+		&Comment{},
+		&ProjectImage{},
+		&ProjectRevision{},
+		&Upload{},
+		&RoleQuota{},
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type ProjectImage struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ProjectID uint  `json:"project_id"`
+	Path      Media `swaggertype:"string"`
+	Caption   string
+	AltText   string `json:"alt_text"`
+	Position  int
+	Cover     bool
+}
+
+type ProjectImageInput struct {
+	Caption string `form:"caption"`
+	AltText string `form:"alt_text"`
+}
+
+type ProjectImagesOrderInput struct {
+	ImageIDs []uint `json:"image_ids" binding:"required"`
+}
@@ -1,56 +1,61 @@
 package models
 
 import (
-	"errors"
-	"net/http"
-	"partage-projets/config"
-	"strconv"
 	"time"
 
-	"github.com/gin-gonic/gin"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
+const (
+	VisibilityPublic   = "public"
+	VisibilityUnlisted = "unlisted"
+	VisibilityPrivate  = "private"
+)
+
+const (
+	StatusDraft     = "draft"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+)
+
+// Project is rendered with the number of its likes rather than the likers, and the IDs of its collaborators,
+// since the users' details aren't public. LikedByMe is only rendered for authenticated requests.
+// Version is incremented by every change of the project.
 type Project struct {
-	ID          uint `gorm:"primaryKey"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Name        string `binding:"required"`
-	Description string `binding:"required"`
-	Image       string
-	Skills      datatypes.JSONSlice[string] `gorm:"type:json" swaggertype:"array,string"`
-	Comments    []Comment                   `gorm:"foreignKey:ProjectID"`
-	Likes       []User                      `gorm:"many2many:project_likes"`
+	ID              uint `gorm:"primaryKey"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	Name            string
+	Description     string
+	Image           Media                       `swaggertype:"string"`
+	Skills          datatypes.JSONSlice[string] `gorm:"type:json" swaggertype:"array,string"`
+	Comments        []Comment                   `gorm:"foreignKey:ProjectID"`
+	Images          []ProjectImage              `gorm:"foreignKey:ProjectID"`
+	Revisions       []ProjectRevision           `gorm:"foreignKey:ProjectID" json:"-"`
+	Likes           []User                      `gorm:"many2many:project_likes" json:"-"`
+	LikesCount      int                         `gorm:"-" json:"likes_count"`
+	LikedByMe       *bool                       `gorm:"-" json:"liked_by_me,omitempty"`
+	OwnerID         *uint                       `json:"owner_id"`
+	Visibility      string                      `gorm:"default:public" json:"visibility" enums:"public,unlisted,private"`
+	Collaborators   []User                      `gorm:"many2many:project_collaborators" json:"-"`
+	CollaboratorIDs []uint                      `gorm:"-" json:"collaborator_ids"`
+	Status          string                      `json:"status" enums:"draft,published,archived"`
+	PublishedAt     *time.Time                  `json:"published_at"`
+	Version         uint                        `gorm:"default:1" json:"version"`
+	DeletedAt       gorm.DeletedAt              `gorm:"index" json:"deleted_at" swaggertype:"string" format:"date-time"`
 }
 
-type ProjectUpdateInput struct {
-	Name        *string   `json:"name"`
-	Description *string   `json:"description"`
-	Skills      *[]string `json:"skills"`
+type ProjectCreateInput struct {
+	Name        string   `json:"name" form:"name" binding:"required"`
+	Description string   `json:"description" form:"description" binding:"required"`
+	Skills      []string `json:"skills" form:"skills"`
+	Visibility  string   `json:"visibility" form:"visibility" binding:"omitempty,oneof=public unlisted private" enums:"public,unlisted,private"`
 }
 
-func FindProjectById(context *gin.Context) (project *Project, err error) {
-	idParam := context.Param("id")
-	id, err := strconv.Atoi(idParam)
-
-	if err != nil {
-		context.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID."})
-
-		return nil, err
-	}
-
-	if err = config.DB.Preload("Likes").Preload("Comments").First(&project, id).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			context.JSON(http.StatusNotFound, gin.H{"error": "Project not found."})
-
-			return nil, err
-		}
-
-		context.JSON(http.StatusInternalServerError, gin.H{"error": "Unable to fetch project."})
-
-		return nil, err
-	}
-
-	return project, nil
+type ProjectUpdateInput struct {
+	Name        *string   `json:"name" form:"name"`
+	Description *string   `json:"description" form:"description"`
+	Skills      *[]string `json:"skills" form:"skills"`
+	Visibility  *string   `json:"visibility" form:"visibility" binding:"omitempty,oneof=public unlisted private" enums:"public,unlisted,private"`
 }
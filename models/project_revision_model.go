@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// ProjectRevision records a change of a project: who made it, when, the fields it changed,
+// and the state of the project afterwards, which the owner can go back to.
+type ProjectRevision struct {
+	ID          uint                        `gorm:"primaryKey" json:"id"`
+	CreatedAt   time.Time                   `json:"created_at"`
+	ProjectID   uint                        `json:"project_id"`
+	UserID      *uint                       `json:"user_id"`
+	Name        string                      `json:"name"`
+	Description string                      `json:"description"`
+	Image       Media                       `json:"image" swaggertype:"string"`
+	Skills      datatypes.JSONSlice[string] `gorm:"type:json" json:"skills" swaggertype:"array,string"`
+	Visibility  string                      `json:"visibility"`
+	Changes     datatypes.JSONSlice[Change] `gorm:"type:json" json:"changes" swaggertype:"array,object"`
+}
+
+// Change is the previous and new value of a field, named after its column.
+type Change struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DefaultQuotaBytes int64 = 50 << 20
+	DefaultQuotaFiles int64 = 100
+)
+
+var ErrQuotaExceeded = errors.New("upload quota exceeded")
+
+type Upload struct {
+	ID        uint `gorm:"primaryKey"`
+	CreatedAt time.Time
+	UserID    uint   `gorm:"index"`
+	Path      string `gorm:"index"`
+	Size      int64
+}
+
+// RoleQuota limits the storage of every user having the role. A limit of 0 means unlimited.
+type RoleQuota struct {
+	Role      string `gorm:"primaryKey" json:"role"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	MaxBytes  int64 `json:"max_bytes" binding:"min=0"`
+	MaxFiles  int64 `json:"max_files" binding:"min=0"`
+}
+
+type Usage struct {
+	Bytes    int64 `json:"bytes"`
+	Files    int64 `json:"files"`
+	MaxBytes int64 `json:"max_bytes"`
+	MaxFiles int64 `json:"max_files"`
+}
@@ -1,13 +1,24 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
 
 type User struct {
 	ID            uint `gorm:"primaryKey"`
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
-	Email         string    `gorm:"unique" binding:"required,email"`
-	Password      string    `binding:"required,min=8"`
-	Comments      []Comment `gorm:"foreignKey:UserID"`
-	LikedProjects []Project `gorm:"many2many:project_likes"`
+	Email         string         `gorm:"unique" binding:"required,email"`
+	Password      string         `binding:"required,min=8"`
+	Role          string         `gorm:"default:user" swaggerignore:"true"`
+	Comments      []Comment      `gorm:"foreignKey:UserID"`
+	LikedProjects []Project      `gorm:"many2many:project_likes"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
 }
@@ -0,0 +1,60 @@
+package problem
+
+import "partage-projets/i18n"
+
+// Code identifies an error in a stable, machine-readable way. Clients should rely on it rather than on the detail.
+type Code string
+
+const (
+	InvalidData            Code = "invalid_data"
+	InvalidID              Code = "invalid_id"
+	NoDataToUpdate         Code = "no_data_to_update"
+	InvalidCredentials     Code = "invalid_credentials"
+	EmailAlreadyUsed       Code = "email_already_used"
+	Unauthorized           Code = "unauthorized"
+	InvalidToken           Code = "invalid_token"
+	Forbidden              Code = "forbidden"
+	RouteNotFound          Code = "route_not_found"
+	ProjectNotFound        Code = "project_not_found"
+	ProjectArchived        Code = "project_archived"
+	InvalidStatusChange    Code = "invalid_status_change"
+	RestoreExpired         Code = "restore_expired"
+	CommentNotFound        Code = "comment_not_found"
+	RevisionNotFound       Code = "revision_not_found"
+	PreconditionFailed     Code = "precondition_failed"
+	PreconditionRequired   Code = "precondition_required"
+	UserNotFound           Code = "user_not_found"
+	ImageNotFound          Code = "image_not_found"
+	MediaNotFound          Code = "media_not_found"
+	ImageRequired          Code = "image_required"
+	UnsupportedImageFormat Code = "unsupported_image_format"
+	InvalidImage           Code = "invalid_image"
+	InvalidImageOrder      Code = "invalid_image_order"
+	UnknownRole            Code = "unknown_role"
+	QuotaExceeded          Code = "quota_exceeded"
+	TooManyRequests        Code = "too_many_requests"
+	InternalError          Code = "internal_error"
+	PasswordTooShort       Code = "password_too_short"
+	PasswordMissingUpper   Code = "password_missing_uppercase"
+	PasswordMissingLower   Code = "password_missing_lowercase"
+	PasswordMissingNumber  Code = "password_missing_number"
+	PasswordMissingSpecial Code = "password_missing_special"
+	FieldRequired          Code = "required"
+	FieldEmail             Code = "email"
+	FieldMin               Code = "min"
+	FieldMax               Code = "max"
+	FieldMinLength         Code = "min_length"
+	FieldMaxLength         Code = "max_length"
+	FieldOneOf             Code = "one_of"
+	FieldInvalid           Code = "invalid"
+)
+
+// Error lets a code be returned as an error, for instance by validation helpers.
+func (code Code) Error() string {
+	return code.Message(i18n.English)
+}
+
+// Message returns the detail of the code in the language, formatted with args. Details are kept in the i18n catalogs.
+func (code Code) Message(language string, args ...any) string {
+	return i18n.Translate(language, string(code), args...)
+}
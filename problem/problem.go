@@ -0,0 +1,167 @@
+package problem
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"partage-projets/i18n"
+	"partage-projets/logging"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+const ContentType = "application/problem+json"
+
+// Problem is an RFC 7807 error response.
+type Problem struct {
+	Type      string       `json:"type" example:"urn:partage-projets:problem:invalid_data"`
+	Title     string       `json:"title" example:"Bad Request"`
+	Status    int          `json:"status" example:"400"`
+	Detail    string       `json:"detail" example:"Invalid data."`
+	Instance  string       `json:"instance" example:"/projects/1"`
+	Code      Code         `json:"code" swaggertype:"string" example:"invalid_data"`
+	RequestID string       `json:"request_id,omitempty" example:"5f2b6c1d9e8a7b3c4d2e1f0a9b8c7d6e"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes why a field of the request body was rejected.
+type FieldError struct {
+	Field  string `json:"field" example:"email"`
+	Code   Code   `json:"code" swaggertype:"string" example:"required"`
+	Detail string `json:"detail" example:"This field is required."`
+
+	args []any
+}
+
+func init() {
+	// Field errors are reported with their JSON name, as sent by the client.
+	if validate, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+
+			if name == "" {
+				return field.Name
+			}
+
+			return name
+		})
+	}
+}
+
+func New(context *gin.Context, status int, code Code, args ...any) Problem {
+	problem := Problem{
+		Type:     "urn:partage-projets:problem:" + string(code),
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   code.Message(i18n.FromContext(context), args...),
+		Instance: context.Request.URL.Path,
+		Code:     code,
+	}
+
+	if info := logging.RequestInfoFrom(context.Request.Context()); info != nil {
+		problem.RequestID = info.RequestID
+	}
+
+	return problem
+}
+
+// Field describes a rejected field. Its detail is translated when the problem is rendered.
+func Field(field string, code Code, args ...any) FieldError {
+	return FieldError{Field: field, Code: code, args: args}
+}
+
+// Abort stops the request with the problem of the given code, the detail being formatted with args.
+func Abort(context *gin.Context, status int, code Code, args ...any) {
+	Render(context, New(context, status, code, args...))
+}
+
+// AbortWithDetail stops the request with the problem of the given code, its detail being the message of the given key,
+// for codes whose detail depends on the case.
+func AbortWithDetail(context *gin.Context, status int, code Code, key string, args ...any) {
+	problem := New(context, status, code)
+	problem.Detail = i18n.Translate(i18n.FromContext(context), key, args...)
+
+	Render(context, problem)
+}
+
+// AbortWithFields stops the request with an invalid data problem listing the rejected fields.
+func AbortWithFields(context *gin.Context, fields ...FieldError) {
+	problem := New(context, http.StatusBadRequest, InvalidData)
+
+	for index, field := range fields {
+		fields[index].Detail = field.Code.Message(i18n.FromContext(context), field.args...)
+	}
+
+	problem.Errors = fields
+
+	Render(context, problem)
+}
+
+// Validation stops the request after a binding error, detailing the rejected fields when the body could be decoded.
+func Validation(context *gin.Context, err error) {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		Abort(context, http.StatusBadRequest, InvalidData)
+
+		return
+	}
+
+	fields := make([]FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		fields = append(fields, fieldFromValidation(fieldError))
+	}
+
+	AbortWithFields(context, fields...)
+}
+
+// Internal stops the request with a generic error, the actual error being logged rather than sent to the client.
+func Internal(context *gin.Context, err error) {
+	slog.ErrorContext(context.Request.Context(), "internal error", slog.String("route", context.FullPath()), slog.Any("error", err))
+
+	Abort(context, http.StatusInternalServerError, InternalError)
+}
+
+func Render(context *gin.Context, problem Problem) {
+	context.Header("Content-Type", ContentType)
+	context.Header("Content-Language", i18n.FromContext(context))
+	context.AbortWithStatusJSON(problem.Status, problem)
+}
+
+// NotFound answers requests that match no route.
+func NotFound(context *gin.Context) {
+	Abort(context, http.StatusNotFound, RouteNotFound)
+}
+
+func fieldFromValidation(fieldError validator.FieldError) FieldError {
+	name := fieldError.Field()
+
+	switch fieldError.Tag() {
+	case "required":
+		return Field(name, FieldRequired)
+	case "email":
+		return Field(name, FieldEmail)
+	case "min":
+		if fieldError.Kind() == reflect.String {
+			return Field(name, FieldMinLength, fieldError.Param())
+		}
+
+		return Field(name, FieldMin, fieldError.Param())
+	case "max":
+		if fieldError.Kind() == reflect.String {
+			return Field(name, FieldMaxLength, fieldError.Param())
+		}
+
+		return Field(name, FieldMax, fieldError.Param())
+	case "oneof":
+		return Field(name, FieldOneOf, strings.ReplaceAll(fieldError.Param(), " ", ", "))
+	default:
+		return Field(name, FieldInvalid)
+	}
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"partage-projets/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CommentRepository interface {
+	Find(ctx context.Context, id uint) (*models.Comment, error)
+	Create(ctx context.Context, comment *models.Comment) error
+	Delete(ctx context.Context, comment *models.Comment) error
+	// Purge removes for good the comments deleted before the given time, and returns how many there were.
+	Purge(ctx context.Context, before time.Time) (int64, error)
+}
+
+type gormCommentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &gormCommentRepository{db: db}
+}
+
+func (repository *gormCommentRepository) Find(ctx context.Context, id uint) (*models.Comment, error) {
+	var comment models.Comment
+
+	if err := repository.db.WithContext(ctx).First(&comment, id).Error; err != nil {
+		return nil, translate(err)
+	}
+
+	return &comment, nil
+}
+
+func (repository *gormCommentRepository) Create(ctx context.Context, comment *models.Comment) error {
+	return repository.db.WithContext(ctx).Create(comment).Error
+}
+
+func (repository *gormCommentRepository) Delete(ctx context.Context, comment *models.Comment) error {
+	return repository.db.WithContext(ctx).Delete(comment).Error
+}
+
+func (repository *gormCommentRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result := repository.db.WithContext(ctx).Unscoped().Where("deleted_at < ?", before).Delete(&models.Comment{})
+
+	return result.RowsAffected, result.Error
+}
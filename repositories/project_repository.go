@@ -0,0 +1,324 @@
+package repositories
+
+import (
+	"context"
+	"partage-projets/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ProjectRepository interface {
+	// List returns the public projects that aren't drafts, along with every project the viewer owns or collaborates on.
+	List(ctx context.Context, viewerID *uint) ([]models.Project, error)
+	Find(ctx context.Context, id uint) (*models.Project, error)
+	Create(ctx context.Context, project *models.Project) error
+	// Update applies the updates and increments the version of the project,
+	// or returns ErrStale if its version changed since it was read.
+	Update(ctx context.Context, project *models.Project, updates map[string]any) error
+	// Revise is Update recording the revision in the same transaction.
+	Revise(ctx context.Context, project *models.Project, updates map[string]any, revision *models.ProjectRevision) error
+	// ListRevisions returns the revisions of the project, most recent first.
+	ListRevisions(ctx context.Context, projectID uint) ([]models.ProjectRevision, error)
+	FindRevision(ctx context.Context, projectID uint, revisionID uint) (*models.ProjectRevision, error)
+	// Delete moves the project to the trash, keeping its gallery, comments and likes until it is purged.
+	// It returns ErrStale if the version of the project changed since it was read.
+	Delete(ctx context.Context, project *models.Project) error
+	// ListDeleted returns the projects in the trash that the user owns, most recently deleted first.
+	ListDeleted(ctx context.Context, userID uint) ([]models.Project, error)
+	FindDeleted(ctx context.Context, id uint) (*models.Project, error)
+	Restore(ctx context.Context, project *models.Project) error
+	// ListExpired returns the projects deleted before the given time, with their gallery and revisions.
+	ListExpired(ctx context.Context, before time.Time) ([]models.Project, error)
+	// Purge removes the project for good, along with its gallery, comments, likes, collaborators and revisions, in a single transaction.
+	Purge(ctx context.Context, project *models.Project) error
+	AddLike(ctx context.Context, project *models.Project, userID uint) error
+	RemoveLike(ctx context.Context, project *models.Project, userID uint) error
+	AddCollaborator(ctx context.Context, project *models.Project, userID uint) error
+	RemoveCollaborator(ctx context.Context, project *models.Project, userID uint) error
+
+	FindImage(ctx context.Context, projectID uint, imageID uint) (*models.ProjectImage, error)
+	ListImages(ctx context.Context, projectID uint) ([]models.ProjectImage, error)
+	CreateImage(ctx context.Context, image *models.ProjectImage) error
+	ReorderImages(ctx context.Context, positions map[uint]int) error
+	SetCover(ctx context.Context, image *models.ProjectImage) error
+	// DeleteImage removes the image, handing the cover over to the first remaining image if needed.
+	DeleteImage(ctx context.Context, image *models.ProjectImage) error
+}
+
+type gormProjectRepository struct {
+	db *gorm.DB
+}
+
+func NewProjectRepository(db *gorm.DB) ProjectRepository {
+	return &gormProjectRepository{db: db}
+}
+
+func orderImages(db *gorm.DB) *gorm.DB {
+	return db.Order("position, id")
+}
+
+func (repository *gormProjectRepository) withDetails(ctx context.Context) *gorm.DB {
+	return repository.db.WithContext(ctx).Preload("Likes").Preload("Collaborators").Preload("Comments").Preload("Images", orderImages)
+}
+
+func (repository *gormProjectRepository) List(ctx context.Context, viewerID *uint) ([]models.Project, error) {
+	var projects []models.Project
+
+	query := repository.withDetails(ctx).Where("visibility = ? AND status <> ?", models.VisibilityPublic, models.StatusDraft)
+
+	if viewerID != nil {
+		collaborations := repository.db.Table("project_collaborators").Select("project_id").Where("user_id = ?", *viewerID)
+		query = query.Or("owner_id = ?", *viewerID).Or("id IN (?)", collaborations)
+	}
+
+	if err := query.Find(&projects).Error; err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (repository *gormProjectRepository) Find(ctx context.Context, id uint) (*models.Project, error) {
+	var project models.Project
+
+	if err := repository.withDetails(ctx).First(&project, id).Error; err != nil {
+		return nil, translate(err)
+	}
+
+	return &project, nil
+}
+
+func (repository *gormProjectRepository) Create(ctx context.Context, project *models.Project) error {
+	return repository.db.WithContext(ctx).Create(project).Error
+}
+
+func (repository *gormProjectRepository) Update(ctx context.Context, project *models.Project, updates map[string]any) error {
+	return update(repository.db.WithContext(ctx), project, updates)
+}
+
+func (repository *gormProjectRepository) Revise(ctx context.Context, project *models.Project, updates map[string]any, revision *models.ProjectRevision) error {
+	return repository.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := update(tx, project, updates); err != nil {
+			return err
+		}
+
+		return tx.Create(revision).Error
+	})
+}
+
+// update only matches the version that was read, so that concurrent changes aren't overwritten.
+func update(db *gorm.DB, project *models.Project, updates map[string]any) error {
+	updates["version"] = gorm.Expr("version + 1")
+
+	result := db.Model(project).Where("version = ?", project.Version).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrStale
+	}
+
+	project.Version++
+
+	return nil
+}
+
+func (repository *gormProjectRepository) ListRevisions(ctx context.Context, projectID uint) ([]models.ProjectRevision, error) {
+	var revisions []models.ProjectRevision
+
+	if err := repository.db.WithContext(ctx).Where("project_id = ?", projectID).Order("id DESC").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (repository *gormProjectRepository) FindRevision(ctx context.Context, projectID uint, revisionID uint) (*models.ProjectRevision, error) {
+	var revision models.ProjectRevision
+
+	if err := repository.db.WithContext(ctx).Where("project_id = ?", projectID).First(&revision, revisionID).Error; err != nil {
+		return nil, translate(err)
+	}
+
+	return &revision, nil
+}
+
+func (repository *gormProjectRepository) Delete(ctx context.Context, project *models.Project) error {
+	result := repository.db.WithContext(ctx).Where("version = ?", project.Version).Delete(project)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrStale
+	}
+
+	return nil
+}
+
+func (repository *gormProjectRepository) ListDeleted(ctx context.Context, userID uint) ([]models.Project, error) {
+	var projects []models.Project
+
+	err := repository.withDetails(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND owner_id = ?", userID).
+		Order("deleted_at DESC").
+		Find(&projects).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+func (repository *gormProjectRepository) FindDeleted(ctx context.Context, id uint) (*models.Project, error) {
+	var project models.Project
+
+	if err := repository.withDetails(ctx).Unscoped().Where("deleted_at IS NOT NULL").First(&project, id).Error; err != nil {
+		return nil, translate(err)
+	}
+
+	return &project, nil
+}
+
+func (repository *gormProjectRepository) Restore(ctx context.Context, project *models.Project) error {
+	return repository.db.WithContext(ctx).Unscoped().Model(project).Update("deleted_at", nil).Error
+}
+
+func (repository *gormProjectRepository) ListExpired(ctx context.Context, before time.Time) ([]models.Project, error) {
+	var projects []models.Project
+
+	err := repository.db.WithContext(ctx).Unscoped().Preload("Images").Preload("Revisions").Where("deleted_at < ?", before).Find(&projects).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// Purge deletes the dependent rows explicitly rather than relying on the cascading foreign keys alone,
+// so that nothing is left behind on a database that doesn't enforce them.
+func (repository *gormProjectRepository) Purge(ctx context.Context, project *models.Project) error {
+	return repository.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, association := range []string{"Likes", "Collaborators"} {
+			if err := tx.Model(project).Association(association).Clear(); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Unscoped().Where("project_id = ?", project.ID).Delete(&models.Comment{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("project_id = ?", project.ID).Delete(&models.ProjectImage{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("project_id = ?", project.ID).Delete(&models.ProjectRevision{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Delete(project).Error
+	})
+}
+
+func (repository *gormProjectRepository) AddLike(ctx context.Context, project *models.Project, userID uint) error {
+	var user models.User
+
+	db := repository.db.WithContext(ctx)
+
+	if err := db.First(&user, userID).Error; err != nil {
+		return translate(err)
+	}
+
+	return db.Model(project).Association("Likes").Append(&user)
+}
+
+func (repository *gormProjectRepository) RemoveLike(ctx context.Context, project *models.Project, userID uint) error {
+	return repository.db.WithContext(ctx).Model(project).Association("Likes").Delete(&models.User{ID: userID})
+}
+
+func (repository *gormProjectRepository) AddCollaborator(ctx context.Context, project *models.Project, userID uint) error {
+	var user models.User
+
+	db := repository.db.WithContext(ctx)
+
+	if err := db.First(&user, userID).Error; err != nil {
+		return translate(err)
+	}
+
+	return db.Model(project).Association("Collaborators").Append(&user)
+}
+
+func (repository *gormProjectRepository) RemoveCollaborator(ctx context.Context, project *models.Project, userID uint) error {
+	return repository.db.WithContext(ctx).Model(project).Association("Collaborators").Delete(&models.User{ID: userID})
+}
+
+func (repository *gormProjectRepository) FindImage(ctx context.Context, projectID uint, imageID uint) (*models.ProjectImage, error) {
+	var image models.ProjectImage
+
+	if err := repository.db.WithContext(ctx).Where("project_id = ?", projectID).First(&image, imageID).Error; err != nil {
+		return nil, translate(err)
+	}
+
+	return &image, nil
+}
+
+func (repository *gormProjectRepository) ListImages(ctx context.Context, projectID uint) ([]models.ProjectImage, error) {
+	var images []models.ProjectImage
+
+	if err := orderImages(repository.db.WithContext(ctx).Where("project_id = ?", projectID)).Find(&images).Error; err != nil {
+		return nil, err
+	}
+
+	return images, nil
+}
+
+func (repository *gormProjectRepository) CreateImage(ctx context.Context, image *models.ProjectImage) error {
+	return repository.db.WithContext(ctx).Create(image).Error
+}
+
+func (repository *gormProjectRepository) ReorderImages(ctx context.Context, positions map[uint]int) error {
+	return repository.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for id, position := range positions {
+			if err := tx.Model(&models.ProjectImage{}).Where("id = ?", id).Update("position", position).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (repository *gormProjectRepository) SetCover(ctx context.Context, image *models.ProjectImage) error {
+	return repository.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.ProjectImage{}).Where("project_id = ?", image.ProjectID).Update("cover", false).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(image).Update("cover", true).Error
+	})
+}
+
+func (repository *gormProjectRepository) DeleteImage(ctx context.Context, image *models.ProjectImage) error {
+	return repository.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(image).Error; err != nil {
+			return err
+		}
+
+		if !image.Cover {
+			return nil
+		}
+
+		// The first remaining image takes over as cover.
+		var next models.ProjectImage
+
+		result := orderImages(tx.Where("project_id = ?", image.ProjectID)).Limit(1).Find(&next)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+
+		return tx.Model(&next).Update("cover", true).Error
+	})
+}
@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned when the requested record doesn't exist, whatever the storage.
+var ErrNotFound = errors.New("record not found")
+
+// ErrStale is returned when the record was changed by someone else since it was read.
+var ErrStale = errors.New("record changed since it was read")
+
+func translate(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+
+	return err
+}
@@ -0,0 +1,186 @@
+package repositories
+
+import (
+	"context"
+	"partage-projets/models"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+type UploadRepository interface {
+	FindQuota(ctx context.Context, role string) (*models.RoleQuota, error)
+	ListQuotas(ctx context.Context) ([]models.RoleQuota, error)
+	SaveQuota(ctx context.Context, quota *models.RoleQuota) error
+	Usage(ctx context.Context, userID uint) (*models.Usage, error)
+	// Record charges a stored file to the user, or returns models.ErrQuotaExceeded if it would not fit in their quota.
+	// A file the user already owns is not charged twice.
+	Record(ctx context.Context, userID uint, path string, size int64) (*models.Usage, error)
+	DeleteByPath(ctx context.Context, path string) error
+	// Release stops charging the stored file to the users who don't use it anymore, that is who are neither the owner
+	// nor a collaborator of a project, including those in the trash, having it as image or in its gallery.
+	Release(ctx context.Context, path string) error
+	// References counts the projects, including those in the trash, and gallery images using the stored file.
+	References(ctx context.Context, path string) (int64, error)
+	// RevisionReferences counts the revisions keeping the stored file in the history of a project.
+	RevisionReferences(ctx context.Context, path string) (int64, error)
+	// ReferencedPaths lists every stored file used by a project, including those in the trash, a gallery image or a revision.
+	ReferencedPaths(ctx context.Context) (map[string]bool, error)
+}
+
+type gormUploadRepository struct {
+	db *gorm.DB
+}
+
+func NewUploadRepository(db *gorm.DB) UploadRepository {
+	return &gormUploadRepository{db: db}
+}
+
+func (repository *gormUploadRepository) FindQuota(ctx context.Context, role string) (*models.RoleQuota, error) {
+	return findQuota(repository.db.WithContext(ctx), role)
+}
+
+func (repository *gormUploadRepository) ListQuotas(ctx context.Context) ([]models.RoleQuota, error) {
+	var quotas []models.RoleQuota
+
+	if err := repository.db.WithContext(ctx).Order("role").Find(&quotas).Error; err != nil {
+		return nil, err
+	}
+
+	return quotas, nil
+}
+
+func (repository *gormUploadRepository) SaveQuota(ctx context.Context, quota *models.RoleQuota) error {
+	return repository.db.WithContext(ctx).Save(quota).Error
+}
+
+func (repository *gormUploadRepository) Usage(ctx context.Context, userID uint) (*models.Usage, error) {
+	return usage(repository.db.WithContext(ctx), userID)
+}
+
+func (repository *gormUploadRepository) Record(ctx context.Context, userID uint, path string, size int64) (*models.Usage, error) {
+	var current *models.Usage
+
+	err := repository.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+
+		if err := tx.Model(&models.Upload{}).Where("user_id = ? AND path = ?", userID, path).Count(&count).Error; err != nil {
+			return err
+		}
+
+		var err error
+
+		current, err = usage(tx, userID)
+		if err != nil || count > 0 {
+			return err
+		}
+
+		if (current.MaxBytes > 0 && current.Bytes+size > current.MaxBytes) || (current.MaxFiles > 0 && current.Files+1 > current.MaxFiles) {
+			return models.ErrQuotaExceeded
+		}
+
+		return tx.Create(&models.Upload{UserID: userID, Path: path, Size: size}).Error
+	})
+
+	return current, err
+}
+
+func (repository *gormUploadRepository) DeleteByPath(ctx context.Context, path string) error {
+	return repository.db.WithContext(ctx).Where("path = ?", path).Delete(&models.Upload{}).Error
+}
+
+func (repository *gormUploadRepository) Release(ctx context.Context, path string) error {
+	db := repository.db.WithContext(ctx)
+
+	using := db.Unscoped().Model(&models.Project{}).
+		Where("image = ? OR id IN (?)", path, db.Model(&models.ProjectImage{}).Select("project_id").Where("path = ?", path))
+	owners := using.Session(&gorm.Session{}).Select("owner_id").Where("owner_id IS NOT NULL")
+	collaborators := db.Table("project_collaborators").Select("user_id").Where("project_id IN (?)", using.Session(&gorm.Session{}).Select("id"))
+
+	return db.Where("path = ? AND user_id NOT IN (?) AND user_id NOT IN (?)", path, owners, collaborators).Delete(&models.Upload{}).Error
+}
+
+func (repository *gormUploadRepository) References(ctx context.Context, path string) (int64, error) {
+	var projects, images int64
+
+	db := repository.db.WithContext(ctx)
+
+	if err := db.Unscoped().Model(&models.Project{}).Where("image = ?", path).Count(&projects).Error; err != nil {
+		return 0, err
+	}
+
+	if err := db.Model(&models.ProjectImage{}).Where("path = ?", path).Count(&images).Error; err != nil {
+		return 0, err
+	}
+
+	return projects + images, nil
+}
+
+func (repository *gormUploadRepository) RevisionReferences(ctx context.Context, path string) (int64, error) {
+	var revisions int64
+
+	if err := repository.db.WithContext(ctx).Model(&models.ProjectRevision{}).Where("image = ?", path).Count(&revisions).Error; err != nil {
+		return 0, err
+	}
+
+	return revisions, nil
+}
+
+func (repository *gormUploadRepository) ReferencedPaths(ctx context.Context) (map[string]bool, error) {
+	var projectImages, galleryImages, revisionImages []string
+
+	db := repository.db.WithContext(ctx)
+
+	if err := db.Unscoped().Model(&models.Project{}).Where("image <> ''").Pluck("image", &projectImages).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Model(&models.ProjectImage{}).Pluck("path", &galleryImages).Error; err != nil {
+		return nil, err
+	}
+
+	if err := db.Model(&models.ProjectRevision{}).Where("image <> ''").Pluck("image", &revisionImages).Error; err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]bool)
+	for _, path := range slices.Concat(projectImages, galleryImages, revisionImages) {
+		paths[path] = true
+	}
+
+	return paths, nil
+}
+
+func findQuota(db *gorm.DB, role string) (*models.RoleQuota, error) {
+	quota := models.RoleQuota{Role: role, MaxBytes: models.DefaultQuotaBytes, MaxFiles: models.DefaultQuotaFiles}
+
+	if err := db.Where("role = ?", role).Limit(1).Find(&quota).Error; err != nil {
+		return nil, err
+	}
+
+	return &quota, nil
+}
+
+func usage(db *gorm.DB, userID uint) (*models.Usage, error) {
+	var user models.User
+
+	if err := db.First(&user, userID).Error; err != nil {
+		return nil, translate(err)
+	}
+
+	quota, err := findQuota(db, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	current := models.Usage{MaxBytes: quota.MaxBytes, MaxFiles: quota.MaxFiles}
+
+	err = db.Model(&models.Upload{}).Where("user_id = ?", userID).
+		Select("COALESCE(SUM(size), 0) AS bytes, COUNT(*) AS files").
+		Row().Scan(&current.Bytes, &current.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	return &current, nil
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"partage-projets/models"
+
+	"gorm.io/gorm"
+)
+
+type UserRepository interface {
+	Find(ctx context.Context, id uint) (*models.User, error)
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	// EmailExists also checks the deleted accounts, whose email stays taken.
+	EmailExists(ctx context.Context, email string) (bool, error)
+	Create(ctx context.Context, user *models.User) error
+}
+
+type gormUserRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &gormUserRepository{db: db}
+}
+
+func (repository *gormUserRepository) Find(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+
+	if err := repository.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, translate(err)
+	}
+
+	return &user, nil
+}
+
+func (repository *gormUserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+
+	if err := repository.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, translate(err)
+	}
+
+	return &user, nil
+}
+
+func (repository *gormUserRepository) EmailExists(ctx context.Context, email string) (bool, error) {
+	var count int64
+
+	if err := repository.db.WithContext(ctx).Unscoped().Model(&models.User{}).Where("email = ?", email).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (repository *gormUserRepository) Create(ctx context.Context, user *models.User) error {
+	return repository.db.WithContext(ctx).Create(user).Error
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"partage-projets/config"
+	"partage-projets/controllers"
+	"partage-projets/middlewares"
+	"partage-projets/models"
+	"partage-projets/repositories"
+
+	"github.com/gin-gonic/gin"
+)
+
+func AdminRoutes(router gin.IRouter, configuration *config.Config, users repositories.UserRepository, quotas *controllers.QuotaController) {
+	routesGroup := router.Group("/admin")
+
+	routesGroup.Use(middlewares.Authentication(configuration.JWTSecret))
+	routesGroup.Use(middlewares.RequireRole(users, models.RoleAdmin))
+
+	{
+		routesGroup.GET("/quotas", quotas.GetQuotas)
+		routesGroup.PUT("/quotas/:role", quotas.PutQuota)
+	}
+}
@@ -1,18 +1,20 @@
 package routes
 
 import (
+	"partage-projets/config"
 	"partage-projets/controllers"
 	"partage-projets/middlewares"
 
 	"github.com/gin-gonic/gin"
 )
 
-func CommentRoutes(router *gin.Engine) {
+func CommentRoutes(router gin.IRouter, configuration *config.Config, comments *controllers.CommentController) {
 	routesGroup := router.Group("/comments")
 
-	routesGroup.Use(middlewares.Authentication())
+	routesGroup.Use(middlewares.Authentication(configuration.JWTSecret))
 
 	{
-		routesGroup.POST("/", controllers.PostComment)
+		routesGroup.POST("/", comments.PostComment)
+		routesGroup.DELETE("/:id", comments.DeleteComment)
 	}
 }
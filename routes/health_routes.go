@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"net/http"
+	"partage-projets/config"
+	"partage-projets/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func HealthRoutes(router *gin.Engine, configuration *config.Config, health *controllers.HealthController) {
+	router.GET("/status", func(context *gin.Context) {
+		context.JSON(http.StatusOK, gin.H{"message": "OK"})
+	})
+
+	router.GET("/healthz", health.Healthz)
+	router.GET("/readyz", health.Readyz)
+	router.GET("/version", health.GetBuildInfo)
+}
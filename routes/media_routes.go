@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"partage-projets/config"
+	"partage-projets/controllers"
+
+	"github.com/gin-gonic/gin"
+)
+
+func MediaRoutes(router *gin.Engine, configuration *config.Config, media *controllers.MediaController) {
+	routesGroup := router.Group("/media")
+
+	{
+		routesGroup.GET("/*filepath", media.GetMedia)
+		routesGroup.HEAD("/*filepath", media.GetMedia)
+	}
+}
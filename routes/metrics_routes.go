@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"partage-projets/config"
+	"partage-projets/metrics"
+	"partage-projets/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+func MetricsRoutes(router *gin.Engine, configuration *config.Config) {
+	router.GET("/metrics", middlewares.RequireBearerToken(configuration.MetricsToken), gin.WrapH(metrics.Handler()))
+}
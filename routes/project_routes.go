@@ -1,23 +1,52 @@
 package routes
 
 import (
+	"partage-projets/config"
 	"partage-projets/controllers"
 	"partage-projets/middlewares"
 
 	"github.com/gin-gonic/gin"
 )
 
-func ProjectRoutes(router *gin.Engine) {
+func ProjectRoutes(router gin.IRouter, configuration *config.Config, projects *controllers.ProjectController) {
 	routesGroup := router.Group("/projects")
 
-	routesGroup.Use(middlewares.Authentication())
+	// Projects can be read anonymously; only the changes require an account.
+	publicGroup := routesGroup.Group("", middlewares.OptionalAuthentication(configuration.JWTSecret))
 
 	{
-		routesGroup.GET("/", controllers.GetProjects)
-		routesGroup.GET("/:id", controllers.GetProject)
-		routesGroup.POST("/", controllers.PostProject)
-		routesGroup.PUT("/:id/like", controllers.LikeProject)
-		routesGroup.PUT("/:id", controllers.PutProject)
-		routesGroup.DELETE("/:id", controllers.DeleteProject)
+		publicGroup.GET("/", projects.GetProjects)
+		publicGroup.GET("/:id", projects.GetProject)
+	}
+
+	privateGroup := routesGroup.Group("", middlewares.Authentication(configuration.JWTSecret))
+
+	{
+		privateGroup.POST("/", projects.PostProject)
+		privateGroup.GET("/:id/revisions", projects.GetProjectRevisions)
+		privateGroup.PUT("/:id/like", projects.LikeProject)
+		privateGroup.PUT("/:id/publish", projects.PublishProject)
+		privateGroup.PUT("/:id/unpublish", projects.UnpublishProject)
+		privateGroup.PUT("/:id/archive", projects.ArchiveProject)
+		privateGroup.POST("/:id/restore", projects.RestoreProject)
+		privateGroup.POST("/:id/revisions/:revisionId/restore", projects.RestoreProjectRevision)
+		privateGroup.POST("/:id/images", projects.PostProjectImage)
+		privateGroup.PUT("/:id/images/order", projects.PutProjectImagesOrder)
+		privateGroup.PUT("/:id/images/:imageId/cover", projects.PutProjectImageCover)
+		privateGroup.DELETE("/:id/images/:imageId", projects.DeleteProjectImage)
+		privateGroup.PUT("/:id/collaborators/:userId", projects.PutProjectCollaborator)
+		privateGroup.DELETE("/:id/collaborators/:userId", projects.DeleteProjectCollaborator)
+	}
+
+	// Changes of a project should send its ETag in If-Match, which can be made mandatory.
+	changeGroup := privateGroup.Group("")
+
+	if configuration.IfMatchRequired {
+		changeGroup.Use(middlewares.RequireIfMatch())
+	}
+
+	{
+		changeGroup.PUT("/:id", projects.PutProject)
+		changeGroup.DELETE("/:id", projects.DeleteProject)
 	}
 }
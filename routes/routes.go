@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"partage-projets/config"
+	"partage-projets/controllers"
+	"partage-projets/middlewares"
+	"partage-projets/repositories"
+	"partage-projets/services"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const V1Prefix = "/api/v1"
+
+// The unversioned routes are kept as aliases of v1 until their sunset, to let clients migrate.
+var (
+	LegacyDeprecation = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	LegacySunset      = time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
+)
+
+// Register builds the repositories, services and controllers on top of the database, and mounts every route.
+// The API is served under /api/v1, and at the root as deprecated aliases.
+// Health checks, metrics and media are not part of the versioned API.
+func Register(router *gin.Engine, configuration *config.Config, db *gorm.DB) {
+	projectRepository := repositories.NewProjectRepository(db)
+	userRepository := repositories.NewUserRepository(db)
+	commentRepository := repositories.NewCommentRepository(db)
+	uploadRepository := repositories.NewUploadRepository(db)
+
+	uploadService := services.NewUploadService(uploadRepository, configuration.UploadDirectory)
+	projectService := services.NewProjectService(projectRepository, uploadService, configuration.TrashRetention)
+	userService := services.NewUserService(userRepository, configuration.JWTSecret, configuration.TokenDuration)
+	commentService := services.NewCommentService(commentRepository, projectRepository, configuration.TrashRetention)
+
+	projectController := controllers.NewProjectController(projectService)
+	userController := controllers.NewUserController(userService, uploadService)
+	commentController := controllers.NewCommentController(commentService)
+	quotaController := controllers.NewQuotaController(uploadService)
+
+	HealthRoutes(router, configuration, controllers.NewHealthController(db, configuration.UploadDirectory))
+	MetricsRoutes(router, configuration)
+	MediaRoutes(router, configuration, controllers.NewMediaController(configuration.UploadDirectory))
+
+	for _, group := range []*gin.RouterGroup{
+		router.Group(V1Prefix),
+		router.Group("", middlewares.Deprecated(LegacyDeprecation, LegacySunset, V1Prefix)),
+	} {
+		ProjectRoutes(group, configuration, projectController)
+		UserRoutes(group, configuration, userController, projectController)
+		CommentRoutes(group, configuration, commentController)
+		AdminRoutes(group, configuration, userRepository, quotaController)
+	}
+}
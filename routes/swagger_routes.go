@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"net/http"
+
+	_ "partage-projets/docs/v1"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// SwaggerRoutes serves the documentation of each API version under /swagger/<version>.
+func SwaggerRoutes(router *gin.Engine) {
+	router.GET("/swagger/v1/*any", ginSwagger.WrapHandler(swaggerFiles.NewHandler(), ginSwagger.InstanceName("v1")))
+
+	router.GET("/swagger/index.html", func(context *gin.Context) {
+		context.Redirect(http.StatusMovedPermanently, "/swagger/v1/index.html")
+	})
+}
@@ -1,16 +1,20 @@
 package routes
 
 import (
+	"partage-projets/config"
 	"partage-projets/controllers"
+	"partage-projets/middlewares"
 
 	"github.com/gin-gonic/gin"
 )
 
-func UserRoutes(router *gin.Engine) {
+func UserRoutes(router gin.IRouter, configuration *config.Config, users *controllers.UserController, projects *controllers.ProjectController) {
 	routesGroup := router.Group("/users")
 
 	{
-		routesGroup.POST("/register", controllers.Register)
-		routesGroup.POST("/login", controllers.Login)
+		routesGroup.POST("/register", users.Register)
+		routesGroup.POST("/login", users.Login)
+		routesGroup.GET("/me/usage", middlewares.Authentication(configuration.JWTSecret), users.GetUsage)
+		routesGroup.GET("/me/trash", middlewares.Authentication(configuration.JWTSecret), projects.GetTrash)
 	}
 }
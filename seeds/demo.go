@@ -0,0 +1,70 @@
+package seeds
+
+import (
+	"partage-projets/models"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const DemoPassword = "Password123!"
+
+// Demo fills the database with a user, an admin, a few published projects (the first one owned by the user) and a comment.
+func Demo(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(DemoPassword), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+
+		user := models.User{
+			Email:    "user1@example.com",
+			Password: string(hashedPassword),
+			Role:     models.RoleUser,
+		}
+		if err := tx.Create(&user).Error; err != nil {
+			return err
+		}
+
+		admin := models.User{
+			Email:    "admin@example.com",
+			Password: string(hashedPassword),
+			Role:     models.RoleAdmin,
+		}
+		if err := tx.Create(&admin).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+
+		project1 := models.Project{
+			Name:        "Test project 1",
+			Description: "Test description 1",
+			OwnerID:     &user.ID,
+			Status:      models.StatusPublished,
+			PublishedAt: &now,
+		}
+		if err := tx.Create(&project1).Error; err != nil {
+			return err
+		}
+
+		project2 := models.Project{
+			Name:        "Test project 2",
+			Description: "Test description 2",
+			Status:      models.StatusPublished,
+			PublishedAt: &now,
+		}
+		if err := tx.Create(&project2).Error; err != nil {
+			return err
+		}
+
+		comment := models.Comment{
+			ProjectID: project1.ID,
+			UserID:    user.ID,
+			Content:   "Test comment on project 1",
+		}
+
+		return tx.Create(&comment).Error
+	})
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"partage-projets/metrics"
+	"partage-projets/models"
+	"partage-projets/repositories"
+	"time"
+)
+
+type CommentService struct {
+	comments       repositories.CommentRepository
+	projects       repositories.ProjectRepository
+	trashRetention time.Duration
+}
+
+// NewCommentService keeps deleted comments for the trash retention before purging them.
+func NewCommentService(comments repositories.CommentRepository, projects repositories.ProjectRepository, trashRetention time.Duration) *CommentService {
+	return &CommentService{comments: comments, projects: projects, trashRetention: trashRetention}
+}
+
+// Create adds the comment to its project, which must be visible to its author and not archived.
+func (service *CommentService) Create(ctx context.Context, comment *models.Comment) error {
+	project, err := service.projects.Find(ctx, comment.ProjectID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return ErrProjectNotFound
+		}
+
+		return err
+	}
+
+	if !canView(project, &comment.UserID) {
+		return ErrProjectNotFound
+	}
+
+	if project.Status == models.StatusArchived {
+		return ErrProjectArchived
+	}
+
+	if err := service.comments.Create(ctx, comment); err != nil {
+		return err
+	}
+
+	metrics.CommentsCreated.Inc()
+
+	return nil
+}
+
+// Delete removes the comment, which only its author or the owner of its project can do.
+func (service *CommentService) Delete(ctx context.Context, userID uint, id uint) error {
+	comment, err := service.comments.Find(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return ErrCommentNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if comment.UserID != userID {
+		project, err := service.projects.Find(ctx, comment.ProjectID)
+		if err != nil && !errors.Is(err, repositories.ErrNotFound) {
+			return err
+		}
+
+		if project == nil || !isOwner(project, userID) {
+			return ErrForbidden
+		}
+	}
+
+	return service.comments.Delete(ctx, comment)
+}
+
+// PurgeExpired removes for good the comments deleted for longer than the trash retention, and returns how many there were.
+func (service *CommentService) PurgeExpired(ctx context.Context) (int64, error) {
+	return service.comments.Purge(ctx, time.Now().Add(-service.trashRetention))
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"partage-projets/models"
+)
+
+var (
+	ErrProjectNotFound        = errors.New("project not found")
+	ErrImageNotFound          = errors.New("image not found")
+	ErrUserNotFound           = errors.New("user not found")
+	ErrForbidden              = errors.New("forbidden")
+	ErrProjectArchived        = errors.New("project is archived")
+	ErrRestoreExpired         = errors.New("project was deleted too long ago to be restored")
+	ErrCommentNotFound        = errors.New("comment not found")
+	ErrRevisionNotFound       = errors.New("revision not found")
+	ErrVersionMismatch        = errors.New("project changed since the given version")
+	ErrEmailAlreadyUsed       = errors.New("email already used")
+	ErrInvalidCredentials     = errors.New("invalid email or password")
+	ErrNoDataToUpdate         = errors.New("no data to update")
+	ErrImageRequired          = errors.New("image is required")
+	ErrInvalidImageOrder      = errors.New("image order must list every image of the project once")
+	ErrUnsupportedImageFormat = errors.New("unsupported image format")
+	ErrInvalidImage           = errors.New("invalid image")
+	ErrInvalidMediaName       = errors.New("media name resolves outside the upload directory")
+	ErrUnknownRole            = errors.New("unknown role")
+)
+
+// FieldError reports a business rule rejecting the value of a field.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (err *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", err.Field, err.Err)
+}
+
+func (err *FieldError) Unwrap() error {
+	return err.Err
+}
+
+// QuotaExceededError carries the usage of the user whose upload was refused, and the size of the refused file.
+type QuotaExceededError struct {
+	Usage models.Usage
+	Size  int64
+}
+
+// BytesExceeded reports whether the file didn't fit in the storage limit. Otherwise, the limit on the number of files was reached.
+func (err *QuotaExceededError) BytesExceeded() bool {
+	return err.Usage.MaxBytes > 0 && err.Usage.Bytes+err.Size > err.Usage.MaxBytes
+}
+
+func (err *QuotaExceededError) Error() string {
+	return models.ErrQuotaExceeded.Error()
+}
+
+func (err *QuotaExceededError) Unwrap() error {
+	return models.ErrQuotaExceeded
+}
+
+// StatusChangeError reports a change of status that the lifecycle of projects doesn't allow.
+type StatusChangeError struct {
+	From string
+	To   string
+}
+
+func (err *StatusChangeError) Error() string {
+	return fmt.Sprintf("a %s project cannot become %s", err.From, err.To)
+}
@@ -0,0 +1,754 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"mime/multipart"
+	"partage-projets/metrics"
+	"partage-projets/models"
+	"partage-projets/repositories"
+	"reflect"
+	"slices"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type ProjectService struct {
+	projects       repositories.ProjectRepository
+	uploads        *UploadService
+	trashRetention time.Duration
+}
+
+// NewProjectService keeps deleted projects restorable for the trash retention.
+func NewProjectService(projects repositories.ProjectRepository, uploads *UploadService, trashRetention time.Duration) *ProjectService {
+	return &ProjectService{projects: projects, uploads: uploads, trashRetention: trashRetention}
+}
+
+// List returns the projects listed for the viewer, who is nil for an anonymous request.
+func (service *ProjectService) List(ctx context.Context, viewerID *uint) ([]models.Project, error) {
+	projects, err := service.projects.List(ctx, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range projects {
+		describe(&projects[index], viewerID)
+	}
+
+	return projects, nil
+}
+
+// Get returns the project as seen by the viewer, who is nil for an anonymous request.
+func (service *ProjectService) Get(ctx context.Context, id uint, viewerID *uint) (*models.Project, error) {
+	project, err := service.Find(ctx, id, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	describe(project, viewerID)
+
+	return project, nil
+}
+
+// Find returns the project if the viewer may see it. A hidden project is reported as not found,
+// so that its existence isn't disclosed.
+func (service *ProjectService) Find(ctx context.Context, id uint, viewerID *uint) (*models.Project, error) {
+	project, err := service.projects.Find(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrProjectNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !canView(project, viewerID) {
+		return nil, ErrProjectNotFound
+	}
+
+	return project, nil
+}
+
+// Create stores the project as a draft owned by the user, along with its image if one was sent.
+func (service *ProjectService) Create(ctx context.Context, userID uint, input models.ProjectCreateInput, file *multipart.FileHeader) (*models.Project, error) {
+	project := &models.Project{
+		Name:        input.Name,
+		Description: input.Description,
+		Skills:      input.Skills,
+		Visibility:  input.Visibility,
+		OwnerID:     &userID,
+		Status:      models.StatusDraft,
+	}
+
+	if project.Visibility == "" {
+		project.Visibility = models.VisibilityPublic
+	}
+
+	if file != nil {
+		name, err := service.uploads.StoreImage(ctx, userID, file)
+		if err != nil {
+			return nil, err
+		}
+
+		project.Image = name
+	}
+
+	// The first revision holds the initial state of the project, and is saved along with it.
+	project.Revisions = []models.ProjectRevision{*newRevision(userID, revisedFields(&models.Project{}), revisedFields(project))}
+
+	if err := service.projects.Create(ctx, project); err != nil {
+		// As for an update, the image is neither kept nor charged if the project couldn't be stored.
+		return nil, errors.Join(err, service.uploads.RemoveUnused(ctx, project.Image))
+	}
+
+	describe(project, &userID)
+
+	metrics.ProjectsCreated.Inc()
+
+	return project, nil
+}
+
+// Update applies the fields present in the input, and replaces the image if one was sent, recording the changes as a revision.
+// Only the members can change the project and only the owner its visibility. Archived projects can't be changed.
+// The version, if given, must be the current one.
+func (service *ProjectService) Update(ctx context.Context, userID uint, id uint, version *uint, input models.ProjectUpdateInput, file *multipart.FileHeader) (*models.Project, error) {
+	project, err := service.findEditable(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkVersion(project, version); err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]any)
+
+	if input.Name != nil {
+		updates["name"] = *input.Name
+	}
+
+	if input.Description != nil {
+		updates["description"] = *input.Description
+	}
+
+	if input.Skills != nil {
+		updates["skills"] = datatypes.JSONSlice[string](*input.Skills)
+	}
+
+	if input.Visibility != nil {
+		if !isOwner(project, userID) {
+			return nil, ErrForbidden
+		}
+
+		updates["visibility"] = *input.Visibility
+	}
+
+	if file != nil {
+		name, err := service.uploads.StoreImage(ctx, userID, file)
+		if err != nil {
+			return nil, err
+		}
+
+		updates["image"] = name
+	}
+
+	if len(updates) == 0 {
+		return nil, ErrNoDataToUpdate
+	}
+
+	if err := service.revise(ctx, userID, project, updates); err != nil {
+		// The new image is neither kept nor charged if the project couldn't be changed, for instance by a concurrent change.
+		if file != nil {
+			return nil, errors.Join(err, service.uploads.RemoveUnused(ctx, updates["image"].(models.Media)))
+		}
+
+		return nil, err
+	}
+
+	describe(project, &userID)
+
+	return project, nil
+}
+
+// Revisions returns the history of the project, most recent first. Only the members can read it,
+// since it keeps the content the project had while it was private or a draft.
+func (service *ProjectService) Revisions(ctx context.Context, userID uint, id uint) ([]models.ProjectRevision, error) {
+	project, err := service.Find(ctx, id, &userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMember(project, userID) {
+		return nil, ErrForbidden
+	}
+
+	return service.projects.ListRevisions(ctx, project.ID)
+}
+
+// RestoreRevision brings the project back to its state after the revision, which is recorded as a new revision.
+// Only the owner can restore a revision, and archived projects can't be changed. An image coming back from the history
+// is charged to the owner again.
+func (service *ProjectService) RestoreRevision(ctx context.Context, userID uint, id uint, revisionID uint) (*models.Project, error) {
+	project, err := service.findEditable(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isOwner(project, userID) {
+		return nil, ErrForbidden
+	}
+
+	revision, err := service.projects.FindRevision(ctx, project.ID, revisionID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrRevisionNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if revision.Image != project.Image {
+		if err := service.uploads.Charge(ctx, userID, revision.Image); err != nil {
+			return nil, err
+		}
+	}
+
+	updates := map[string]any{
+		"name":        revision.Name,
+		"description": revision.Description,
+		"image":       revision.Image,
+		"skills":      revision.Skills,
+		"visibility":  revision.Visibility,
+	}
+
+	if err := service.revise(ctx, userID, project, updates); err != nil {
+		if revision.Image != project.Image {
+			return nil, errors.Join(err, service.uploads.RemoveUnused(ctx, revision.Image))
+		}
+
+		return nil, err
+	}
+
+	describe(project, &userID)
+
+	return project, nil
+}
+
+// revise applies the updates along with a revision of the fields they change, if any,
+// then removes the previous image if nothing refers to it anymore.
+func (service *ProjectService) revise(ctx context.Context, userID uint, project *models.Project, updates map[string]any) error {
+	before := revisedFields(project)
+
+	after := maps.Clone(before)
+	for field, value := range updates {
+		if skills, ok := value.(datatypes.JSONSlice[string]); ok {
+			value = normalizeSkills(skills)
+		}
+
+		after[field] = value
+	}
+
+	revision := newRevision(userID, before, after)
+	if len(revision.Changes) == 0 {
+		return translateStale(service.projects.Update(ctx, project, updates))
+	}
+
+	revision.ProjectID = project.ID
+
+	if err := service.projects.Revise(ctx, project, updates, revision); err != nil {
+		return translateStale(err)
+	}
+
+	if before["image"] != after["image"] {
+		return service.uploads.RemoveUnused(ctx, before["image"].(models.Media))
+	}
+
+	return nil
+}
+
+// Delete moves the project to the trash. Its gallery and files are kept until it is purged.
+// Only the owner can delete it, and archived projects can't be deleted. The version, if given, must be the current one.
+func (service *ProjectService) Delete(ctx context.Context, userID uint, id uint, version *uint) error {
+	project, err := service.findOwnedEditable(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := checkVersion(project, version); err != nil {
+		return err
+	}
+
+	return translateStale(service.projects.Delete(ctx, project))
+}
+
+// Trash returns the deleted projects the user owns, which are the ones they can restore.
+func (service *ProjectService) Trash(ctx context.Context, userID uint) ([]models.Project, error) {
+	projects, err := service.projects.ListDeleted(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range projects {
+		describe(&projects[index], &userID)
+	}
+
+	return projects, nil
+}
+
+// Restore takes the project out of the trash, as long as it was deleted within the trash retention.
+// Like deletion, restoration is reserved to the owner. Collaborators are told they aren't allowed to.
+func (service *ProjectService) Restore(ctx context.Context, userID uint, id uint) (*models.Project, error) {
+	project, err := service.projects.FindDeleted(ctx, id)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrProjectNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMember(project, userID) {
+		return nil, ErrProjectNotFound
+	}
+
+	if !isOwner(project, userID) {
+		return nil, ErrForbidden
+	}
+
+	if time.Since(project.DeletedAt.Time) > service.trashRetention {
+		return nil, ErrRestoreExpired
+	}
+
+	if err := service.projects.Restore(ctx, project); err != nil {
+		return nil, err
+	}
+
+	project.DeletedAt = gorm.DeletedAt{}
+
+	describe(project, &userID)
+
+	return project, nil
+}
+
+// PurgeExpired removes for good the projects deleted for longer than the trash retention,
+// then the files nothing else refers to, and returns the IDs of the purged projects.
+// Names that would resolve outside the upload directory were never stored here, and are skipped rather than removed.
+func (service *ProjectService) PurgeExpired(ctx context.Context) ([]uint, error) {
+	projects, err := service.projects.ListExpired(ctx, time.Now().Add(-service.trashRetention))
+	if err != nil {
+		return nil, err
+	}
+
+	purged := []uint{}
+
+	for index := range projects {
+		project := &projects[index]
+
+		if err := service.projects.Purge(ctx, project); err != nil {
+			return purged, err
+		}
+
+		purged = append(purged, project.ID)
+
+		names := []models.Media{project.Image}
+		for _, image := range project.Images {
+			names = append(names, image.Path)
+		}
+
+		for _, revision := range project.Revisions {
+			names = append(names, revision.Image)
+		}
+
+		for _, name := range names {
+			if err := service.uploads.RemoveUnused(ctx, name); err != nil && !errors.Is(err, ErrInvalidMediaName) {
+				return purged, err
+			}
+		}
+	}
+
+	return purged, nil
+}
+
+// ToggleLike likes the project for the user, or removes their like, and reports whether the project is now liked.
+// Anyone who can see the project can like it, unless it is archived.
+func (service *ProjectService) ToggleLike(ctx context.Context, userID uint, id uint) (bool, error) {
+	project, err := service.findActive(ctx, userID, id)
+	if err != nil {
+		return false, err
+	}
+
+	for _, user := range project.Likes {
+		if user.ID == userID {
+			if err := service.projects.RemoveLike(ctx, project, userID); err != nil {
+				return false, err
+			}
+
+			metrics.ProjectLikes.WithLabelValues("unlike").Inc()
+
+			return false, nil
+		}
+	}
+
+	if err := service.projects.AddLike(ctx, project, userID); err != nil {
+		return false, err
+	}
+
+	metrics.ProjectLikes.WithLabelValues("like").Inc()
+
+	return true, nil
+}
+
+// Publish makes the project visible to everyone allowed by its visibility. Archived projects can be published again.
+func (service *ProjectService) Publish(ctx context.Context, userID uint, id uint) (*models.Project, error) {
+	project, err := service.findForStatusChange(ctx, userID, id, models.StatusPublished, models.StatusDraft, models.StatusArchived)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]any{"status": models.StatusPublished}
+
+	// The first publication date is kept when an archived project is restored.
+	if project.PublishedAt == nil {
+		updates["published_at"] = time.Now()
+	}
+
+	return service.updateStatus(ctx, userID, project, updates)
+}
+
+// Unpublish turns a published project back into a draft.
+func (service *ProjectService) Unpublish(ctx context.Context, userID uint, id uint) (*models.Project, error) {
+	project, err := service.findForStatusChange(ctx, userID, id, models.StatusDraft, models.StatusPublished)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.updateStatus(ctx, userID, project, map[string]any{"status": models.StatusDraft, "published_at": nil})
+}
+
+// Archive makes a published project read-only.
+func (service *ProjectService) Archive(ctx context.Context, userID uint, id uint) (*models.Project, error) {
+	project, err := service.findForStatusChange(ctx, userID, id, models.StatusArchived, models.StatusPublished)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.updateStatus(ctx, userID, project, map[string]any{"status": models.StatusArchived})
+}
+
+// findForStatusChange returns the project if the user owns it and its status can become the given one.
+func (service *ProjectService) findForStatusChange(ctx context.Context, userID uint, id uint, status string, allowedFrom ...string) (*models.Project, error) {
+	project, err := service.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(allowedFrom, project.Status) {
+		return nil, &StatusChangeError{From: project.Status, To: status}
+	}
+
+	return project, nil
+}
+
+func (service *ProjectService) updateStatus(ctx context.Context, userID uint, project *models.Project, updates map[string]any) (*models.Project, error) {
+	if err := service.projects.Update(ctx, project, updates); err != nil {
+		return nil, translateStale(err)
+	}
+
+	describe(project, &userID)
+
+	return project, nil
+}
+
+// AddCollaborator lets the collaborator see and edit the project. Only the owner can manage collaborators,
+// and those of archived projects can't be changed.
+func (service *ProjectService) AddCollaborator(ctx context.Context, userID uint, id uint, collaboratorID uint) (*models.Project, error) {
+	project, err := service.findOwnedEditable(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.projects.AddCollaborator(ctx, project, collaboratorID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+
+		return nil, err
+	}
+
+	return service.Get(ctx, id, &userID)
+}
+
+func (service *ProjectService) RemoveCollaborator(ctx context.Context, userID uint, id uint, collaboratorID uint) (*models.Project, error) {
+	project, err := service.findOwnedEditable(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.projects.RemoveCollaborator(ctx, project, collaboratorID); err != nil {
+		return nil, err
+	}
+
+	return service.Get(ctx, id, &userID)
+}
+
+// AddImage appends the image to the gallery of the project. The first image becomes the cover.
+func (service *ProjectService) AddImage(ctx context.Context, userID uint, id uint, input models.ProjectImageInput, file *multipart.FileHeader) (*models.ProjectImage, error) {
+	project, err := service.findEditable(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if file == nil {
+		return nil, ErrImageRequired
+	}
+
+	name, err := service.uploads.StoreImage(ctx, userID, file)
+	if err != nil {
+		return nil, err
+	}
+
+	image := &models.ProjectImage{
+		ProjectID: project.ID,
+		Path:      name,
+		Caption:   input.Caption,
+		AltText:   input.AltText,
+		Position:  len(project.Images),
+		Cover:     len(project.Images) == 0,
+	}
+
+	if err := service.projects.CreateImage(ctx, image); err != nil {
+		return nil, errors.Join(err, service.uploads.RemoveUnused(ctx, name))
+	}
+
+	return image, nil
+}
+
+// ReorderImages sorts the gallery in the given order, which must list every image of the project once.
+func (service *ProjectService) ReorderImages(ctx context.Context, userID uint, id uint, imageIDs []uint) ([]models.ProjectImage, error) {
+	project, err := service.findEditable(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make(map[uint]int)
+	for position, imageID := range imageIDs {
+		positions[imageID] = position
+	}
+
+	if len(imageIDs) != len(project.Images) || len(positions) != len(project.Images) {
+		return nil, ErrInvalidImageOrder
+	}
+
+	for _, image := range project.Images {
+		if _, ok := positions[image.ID]; !ok {
+			return nil, ErrInvalidImageOrder
+		}
+	}
+
+	if err := service.projects.ReorderImages(ctx, positions); err != nil {
+		return nil, err
+	}
+
+	return service.projects.ListImages(ctx, project.ID)
+}
+
+func (service *ProjectService) SetCover(ctx context.Context, userID uint, id uint, imageID uint) (*models.ProjectImage, error) {
+	image, err := service.findImage(ctx, userID, id, imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.projects.SetCover(ctx, image); err != nil {
+		return nil, err
+	}
+
+	image.Cover = true
+
+	return image, nil
+}
+
+func (service *ProjectService) DeleteImage(ctx context.Context, userID uint, id uint, imageID uint) error {
+	image, err := service.findImage(ctx, userID, id, imageID)
+	if err != nil {
+		return err
+	}
+
+	if err := service.projects.DeleteImage(ctx, image); err != nil {
+		return err
+	}
+
+	return service.uploads.RemoveUnused(ctx, image.Path)
+}
+
+func (service *ProjectService) findImage(ctx context.Context, userID uint, id uint, imageID uint) (*models.ProjectImage, error) {
+	project, err := service.findEditable(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := service.projects.FindImage(ctx, project.ID, imageID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return nil, ErrImageNotFound
+	}
+
+	return image, err
+}
+
+// findEditable returns the project if the user is one of its members and it isn't archived.
+// A project the user can't see is reported as not found, and one they can only see as forbidden.
+func (service *ProjectService) findEditable(ctx context.Context, userID uint, id uint) (*models.Project, error) {
+	project, err := service.Find(ctx, id, &userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isMember(project, userID) {
+		return nil, ErrForbidden
+	}
+
+	if project.Status == models.StatusArchived {
+		return nil, ErrProjectArchived
+	}
+
+	return project, nil
+}
+
+// findActive returns the project if the user may see it and it isn't archived.
+func (service *ProjectService) findActive(ctx context.Context, userID uint, id uint) (*models.Project, error) {
+	project, err := service.Find(ctx, id, &userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.Status == models.StatusArchived {
+		return nil, ErrProjectArchived
+	}
+
+	return project, nil
+}
+
+func (service *ProjectService) findOwned(ctx context.Context, userID uint, id uint) (*models.Project, error) {
+	project, err := service.Find(ctx, id, &userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isOwner(project, userID) {
+		return nil, ErrForbidden
+	}
+
+	return project, nil
+}
+
+// findOwnedEditable returns the project if the user owns it and it isn't archived.
+func (service *ProjectService) findOwnedEditable(ctx context.Context, userID uint, id uint) (*models.Project, error) {
+	project, err := service.findOwned(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if project.Status == models.StatusArchived {
+		return nil, ErrProjectArchived
+	}
+
+	return project, nil
+}
+
+func isOwner(project *models.Project, userID uint) bool {
+	return project.OwnerID != nil && *project.OwnerID == userID
+}
+
+// isMember reports whether the user owns the project or collaborates on it.
+func isMember(project *models.Project, userID uint) bool {
+	return isOwner(project, userID) || slices.ContainsFunc(project.Collaborators, func(user models.User) bool { return user.ID == userID })
+}
+
+// canView reports whether the viewer, who is nil for an anonymous request, may see the project.
+// Private projects and drafts are only visible to their members.
+func canView(project *models.Project, viewerID *uint) bool {
+	if project.Visibility != models.VisibilityPrivate && project.Status != models.StatusDraft {
+		return true
+	}
+
+	return viewerID != nil && isMember(project, *viewerID)
+}
+
+// describe fills in the fields computed from the likes and collaborators of the project.
+func describe(project *models.Project, viewerID *uint) {
+	project.LikesCount = len(project.Likes)
+	project.LikedByMe = nil
+
+	project.CollaboratorIDs = []uint{}
+	for _, user := range project.Collaborators {
+		project.CollaboratorIDs = append(project.CollaboratorIDs, user.ID)
+	}
+
+	if viewerID == nil {
+		return
+	}
+
+	liked := slices.ContainsFunc(project.Likes, func(user models.User) bool { return user.ID == *viewerID })
+	project.LikedByMe = &liked
+}
+
+// revisedFields returns the values of the fields kept in the revisions, by column.
+func revisedFields(project *models.Project) map[string]any {
+	return map[string]any{
+		"name":        project.Name,
+		"description": project.Description,
+		"image":       project.Image,
+		"skills":      normalizeSkills(project.Skills),
+		"visibility":  project.Visibility,
+	}
+}
+
+// normalizeSkills returns the skills as a plain slice, empty rather than nil, so that they can be compared.
+func normalizeSkills(skills datatypes.JSONSlice[string]) []string {
+	if skills == nil {
+		return []string{}
+	}
+
+	return []string(skills)
+}
+
+// newRevision records the state of the fields after a change by the user, along with the fields that changed.
+func newRevision(userID uint, before map[string]any, after map[string]any) *models.ProjectRevision {
+	revision := &models.ProjectRevision{
+		UserID:      &userID,
+		Name:        after["name"].(string),
+		Description: after["description"].(string),
+		Image:       after["image"].(models.Media),
+		Skills:      after["skills"].([]string),
+		Visibility:  after["visibility"].(string),
+		Changes:     []models.Change{},
+	}
+
+	for _, field := range slices.Sorted(maps.Keys(after)) {
+		if !reflect.DeepEqual(before[field], after[field]) {
+			revision.Changes = append(revision.Changes, models.Change{Field: field, From: before[field], To: after[field]})
+		}
+	}
+
+	return revision
+}
+
+// checkVersion returns ErrVersionMismatch if the project was changed since the given version was read.
+func checkVersion(project *models.Project, version *uint) error {
+	if version != nil && *version != project.Version {
+		return ErrVersionMismatch
+	}
+
+	return nil
+}
+
+// translateStale reports a project changed concurrently, between its reading and its update, as a version mismatch.
+func translateStale(err error) error {
+	if errors.Is(err, repositories.ErrStale) {
+		return ErrVersionMismatch
+	}
+
+	return err
+}
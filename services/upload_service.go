@@ -0,0 +1,169 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"mime/multipart"
+	"os"
+	"partage-projets/metrics"
+	"partage-projets/models"
+	"partage-projets/repositories"
+	"path/filepath"
+	"strings"
+
+	"github.com/disintegration/imaging"
+)
+
+// UploadService stores the uploaded files in the directory, and charges them to the quotas of their users.
+type UploadService struct {
+	uploads   repositories.UploadRepository
+	directory string
+}
+
+func NewUploadService(uploads repositories.UploadRepository, directory string) *UploadService {
+	return &UploadService{uploads: uploads, directory: directory}
+}
+
+// StoreImage resizes the image, charges it to the user's quota and stores it, returning its name.
+func (service *UploadService) StoreImage(ctx context.Context, userID uint, file *multipart.FileHeader) (models.Media, error) {
+	format, err := imaging.FormatFromFilename(file.Filename)
+	if err != nil {
+		return "", ErrUnsupportedImageFormat
+	}
+
+	source, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer source.Close()
+
+	img, err := imaging.Decode(source)
+	if err != nil {
+		return "", ErrInvalidImage
+	}
+
+	var buffer bytes.Buffer
+
+	resized := imaging.Resize(img, 800, 0, imaging.Lanczos)
+	if err := imaging.Encode(&buffer, resized, format); err != nil {
+		return "", err
+	}
+
+	// Files are named after the hash of their content, so they can be cached forever.
+	hash := sha256.Sum256(buffer.Bytes())
+	name := models.Media(hex.EncodeToString(hash[:]) + strings.ToLower(filepath.Ext(file.Filename)))
+
+	path, err := service.path(name)
+	if err != nil {
+		return "", err
+	}
+
+	usage, err := service.uploads.Record(ctx, userID, string(name), int64(buffer.Len()))
+	if err != nil {
+		if errors.Is(err, models.ErrQuotaExceeded) {
+			return "", &QuotaExceededError{Usage: *usage, Size: int64(buffer.Len())}
+		}
+
+		return "", err
+	}
+
+	if err := os.MkdirAll(service.directory, 0755); err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(path, buffer.Bytes(), 0644); err != nil {
+		return "", err
+	}
+
+	metrics.UploadBytes.Add(float64(buffer.Len()))
+
+	return name, nil
+}
+
+// RemoveUnused stops charging a stored file to the users who no longer use it, so that replacing an image frees its space
+// in their quotas, while the users sharing it keep being charged. Uploads are content-addressed, so the same file can be
+// charged to several users. The file itself is deleted once no project or gallery image refers to it, unless revisions
+// keep it in the history of a project.
+func (service *UploadService) RemoveUnused(ctx context.Context, name models.Media) error {
+	if name == "" {
+		return nil
+	}
+
+	path, err := service.path(name)
+	if err != nil {
+		return err
+	}
+
+	if err := service.uploads.Release(ctx, string(name)); err != nil {
+		return err
+	}
+
+	references, err := service.uploads.References(ctx, string(name))
+	if err != nil || references > 0 {
+		return err
+	}
+
+	revisions, err := service.uploads.RevisionReferences(ctx, string(name))
+	if err != nil || revisions > 0 {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// Charge charges a stored file to the user again, when a project gets back an image only kept for its history.
+func (service *UploadService) Charge(ctx context.Context, userID uint, name models.Media) error {
+	if name == "" {
+		return nil
+	}
+
+	path, err := service.path(name)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	usage, err := service.uploads.Record(ctx, userID, string(name), info.Size())
+	if errors.Is(err, models.ErrQuotaExceeded) {
+		return &QuotaExceededError{Usage: *usage, Size: info.Size()}
+	}
+
+	return err
+}
+
+// path returns where the stored file is in the upload directory. Names read from the database aren't trusted:
+// one that would resolve outside the directory, such as an absolute path or one going up, is refused.
+func (service *UploadService) path(name models.Media) (string, error) {
+	if !filepath.IsLocal(string(name)) || filepath.Base(string(name)) != string(name) {
+		return "", ErrInvalidMediaName
+	}
+
+	return filepath.Join(service.directory, string(name)), nil
+}
+
+func (service *UploadService) Usage(ctx context.Context, userID uint) (*models.Usage, error) {
+	return service.uploads.Usage(ctx, userID)
+}
+
+func (service *UploadService) Quotas(ctx context.Context) ([]models.RoleQuota, error) {
+	return service.uploads.ListQuotas(ctx)
+}
+
+func (service *UploadService) SaveQuota(ctx context.Context, quota *models.RoleQuota) error {
+	if quota.Role != models.RoleUser && quota.Role != models.RoleAdmin {
+		return ErrUnknownRole
+	}
+
+	return service.uploads.SaveQuota(ctx, quota)
+}
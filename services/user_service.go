@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"partage-projets/metrics"
+	"partage-projets/models"
+	"partage-projets/repositories"
+	"partage-projets/utils"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type CustomClaim struct {
+	UserID uint
+	jwt.RegisteredClaims
+}
+
+type UserService struct {
+	users         repositories.UserRepository
+	secret        string
+	tokenDuration time.Duration
+}
+
+func NewUserService(users repositories.UserRepository, secret string, tokenDuration time.Duration) *UserService {
+	return &UserService{users: users, secret: secret, tokenDuration: tokenDuration}
+}
+
+// Register creates the account with a hashed password and the default role.
+func (service *UserService) Register(ctx context.Context, user *models.User) error {
+	exists, err := service.users.EmailExists(ctx, user.Email)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return ErrEmailAlreadyUsed
+	}
+
+	if err := utils.ValidatePassword(user.Password); err != nil {
+		return &FieldError{Field: "Password", Err: err}
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	user.Password = string(hashedPassword)
+	user.Role = models.RoleUser
+
+	if err := service.users.Create(ctx, user); err != nil {
+		return err
+	}
+
+	metrics.Registrations.Inc()
+
+	return nil
+}
+
+// Login checks the credentials and returns a signed JWT for the user.
+func (service *UserService) Login(ctx context.Context, email string, password string) (string, error) {
+	user, err := service.users.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return "", ErrInvalidCredentials
+		}
+
+		return "", err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return "", ErrInvalidCredentials
+	}
+
+	claim := &CustomClaim{
+		UserID: user.ID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(service.tokenDuration)),
+		},
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claim).SignedString([]byte(service.secret))
+}
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"bytes"
+	"log"
+	"partage-projets/commands"
+	"partage-projets/config"
+	"partage-projets/migrations"
+	"partage-projets/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateUser(testing *testing.T) {
+	InitTest()
+
+	user, err := commands.CreateUser(config.DB, "user3@example.com", "Password123!", models.RoleUser)
+
+	assert.NoError(testing, err)
+	assert.Equal(testing, models.RoleUser, user.Role)
+	assert.NotEqual(testing, "Password123!", user.Password)
+
+	_, err = commands.CreateUser(config.DB, "user3@example.com", "Password123!", models.RoleUser)
+
+	assert.Error(testing, err)
+
+	_, err = commands.CreateUser(config.DB, "user4@example.com", "password", models.RoleUser)
+
+	assert.Error(testing, err)
+}
+
+func TestPromoteAdmin(testing *testing.T) {
+	InitTest()
+
+	assert.NoError(testing, commands.PromoteAdmin(config.DB, "user1@example.com"))
+
+	var user models.User
+	config.DB.Where("email = ?", "user1@example.com").First(&user)
+
+	assert.Equal(testing, models.RoleAdmin, user.Role)
+	assert.Error(testing, commands.PromoteAdmin(config.DB, "unknown@example.com"))
+}
+
+func TestExportImport(testing *testing.T) {
+	InitTest()
+
+	config.DB.Model(&models.Project{ID: 1}).Association("Likes").Append(&models.User{ID: 1})
+	config.DB.Model(&models.Project{ID: 1}).Association("Collaborators").Append(&models.User{ID: 2})
+
+	var buffer bytes.Buffer
+
+	assert.NoError(testing, commands.Export(config.DB, &buffer))
+	assert.Contains(testing, buffer.String(), "Test project 1")
+
+	db := openEmptyDatabase()
+	if _, err := migrations.Up(db); err != nil {
+		log.Fatal("Unable to migrate database: ", err)
+	}
+
+	assert.NoError(testing, commands.Import(db, bytes.NewReader(buffer.Bytes())))
+
+	var project models.Project
+	db.Preload("Likes").Preload("Collaborators").Preload("Comments").First(&project, 1)
+
+	assert.Equal(testing, "Test project 1", project.Name)
+	assert.Len(testing, project.Likes, 1)
+	assert.Len(testing, project.Collaborators, 1)
+	assert.Len(testing, project.Comments, 1)
+
+	var admin models.User
+	db.Where("email = ?", "admin@example.com").First(&admin)
+
+	assert.Equal(testing, models.RoleAdmin, admin.Role)
+}
+
+func TestImportIntoNonEmptyDatabase(testing *testing.T) {
+	InitTest()
+
+	var buffer bytes.Buffer
+
+	assert.NoError(testing, commands.Export(config.DB, &buffer))
+	assert.Error(testing, commands.Import(config.DB, &buffer))
+}
+
+func TestRunUnknownCommand(testing *testing.T) {
+	assert.Equal(testing, 2, commands.Run([]string{"unknown"}))
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"partage-projets/problem"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,7 +25,7 @@ func TestPostComment(testing *testing.T) {
 		log.Fatal("Unable to marshal data: ", err)
 	}
 
-	request, err := http.NewRequest(http.MethodPost, "/comments/", bytes.NewBuffer(data))
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/comments/", bytes.NewBuffer(data))
 	if err != nil {
 		log.Fatal("Unable to create request: ", err)
 	}
@@ -43,3 +44,28 @@ func TestPostComment(testing *testing.T) {
 
 	assert.Contains(testing, body, "Test comment")
 }
+
+func TestPostCommentProjectNotFound(testing *testing.T) {
+	router := InitTest()
+
+	data, err := json.Marshal(map[string]interface{}{"project_id": 42, "content": "Test comment"})
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/comments/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Equal(testing, problem.ProjectNotFound, decodeProblem(response).Code)
+}
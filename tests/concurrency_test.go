@@ -0,0 +1,192 @@
+package tests
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"partage-projets/config"
+	"partage-projets/models"
+	"partage-projets/problem"
+	"partage-projets/repositories"
+	"partage-projets/routes"
+	"partage-projets/services"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestProjectETag(testing *testing.T) {
+	router := InitTest()
+
+	response := sendAs(router, 1, http.MethodPost, "/api/v1/projects/", map[string]string{"name": "Concurrent project", "description": "Description"})
+	url := "/api/v1/projects/" + formatID(decodeProject(response).ID)
+
+	assert.Equal(testing, `"1"`, response.Header().Get("ETag"))
+
+	response = sendAs(router, 1, http.MethodGet, url, nil)
+
+	assert.Equal(testing, `"1"`, response.Header().Get("ETag"))
+	assert.Equal(testing, uint(1), decodeProject(response).Version)
+
+	response = sendAs(router, 1, http.MethodPut, url, map[string]string{"name": "Renamed project"}, map[string]string{"If-Match": `"1"`})
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, `"2"`, response.Header().Get("ETag"))
+	assert.Equal(testing, uint(2), decodeProject(response).Version)
+
+	// Without If-Match, the change is applied whatever the version.
+	response = sendAs(router, 1, http.MethodPut, url, map[string]string{"name": "Renamed again"})
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, `"3"`, response.Header().Get("ETag"))
+
+	// Likes change the body but not the version, since the tag only covers the editable fields.
+	sendAs(router, 1, http.MethodPut, url+"/like", nil)
+
+	response = sendAs(router, 1, http.MethodGet, url, nil)
+
+	assert.Equal(testing, 1, decodeProject(response).LikesCount)
+	assert.Equal(testing, `"3"`, response.Header().Get("ETag"))
+}
+
+func TestPutProjectStaleIfMatch(testing *testing.T) {
+	router := InitTest()
+
+	url := "/api/v1/projects/" + formatID(decodeProject(sendAs(router, 1, http.MethodPost, "/api/v1/projects/", map[string]string{"name": "First name", "description": "Description"})).ID)
+
+	// Two changes are based on the first version: the second one would overwrite the first and is refused.
+	assert.Equal(testing, http.StatusOK, sendAs(router, 1, http.MethodPut, url, map[string]string{"name": "Second name"}, map[string]string{"If-Match": `"1"`}).Code)
+
+	response := sendAs(router, 1, http.MethodPut, url, map[string]string{"name": "Third name"}, map[string]string{"If-Match": `"1"`})
+
+	assert.Equal(testing, http.StatusPreconditionFailed, response.Code)
+	assert.Equal(testing, problem.PreconditionFailed, decodeProblem(response).Code)
+
+	for _, etag := range []string{`W/"2"`, "2", `"two"`} {
+		assert.Equal(testing, http.StatusPreconditionFailed, sendAs(router, 1, http.MethodPut, url, map[string]string{"name": "Third name"}, map[string]string{"If-Match": etag}).Code, etag)
+	}
+
+	project := decodeProject(sendAs(router, 1, http.MethodGet, url, nil))
+
+	assert.Equal(testing, "Second name", project.Name)
+	assert.Equal(testing, uint(2), project.Version)
+	assert.Len(testing, decodeRevisions(sendAs(router, 1, http.MethodGet, url+"/revisions", nil)), 2)
+
+	assert.Equal(testing, http.StatusOK, sendAs(router, 1, http.MethodPut, url, map[string]string{"name": "Third name"}, map[string]string{"If-Match": "*"}).Code)
+}
+
+func TestDeleteProjectStaleIfMatch(testing *testing.T) {
+	router := InitTest()
+
+	url := "/api/v1/projects/" + formatID(decodeProject(sendAs(router, 1, http.MethodPost, "/api/v1/projects/", map[string]string{"name": "First name", "description": "Description"})).ID)
+
+	sendAs(router, 1, http.MethodPut, url, map[string]string{"name": "Second name"})
+
+	response := sendAs(router, 1, http.MethodDelete, url, nil, map[string]string{"If-Match": `"1"`})
+
+	assert.Equal(testing, http.StatusPreconditionFailed, response.Code)
+	assert.Equal(testing, http.StatusOK, sendAs(router, 1, http.MethodGet, url, nil).Code)
+
+	// If-Match uses the strong comparison, so the current version sent as a weak tag doesn't match either.
+	assert.Equal(testing, http.StatusPreconditionFailed, sendAs(router, 1, http.MethodDelete, url, nil, map[string]string{"If-Match": `W/"2"`}).Code)
+
+	response = sendAs(router, 1, http.MethodDelete, url, nil, map[string]string{"If-Match": `"2"`})
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, http.StatusNotFound, sendAs(router, 1, http.MethodGet, url, nil).Code)
+}
+
+func TestIfMatchRequired(testing *testing.T) {
+	InitTest()
+
+	configuration := NewTestConfig()
+	configuration.IfMatchRequired = true
+
+	router := gin.New()
+	routes.Register(router, configuration, config.DB)
+
+	response := sendAs(router, 1, http.MethodPut, "/api/v1/projects/1", map[string]string{"name": "Renamed project"})
+
+	assert.Equal(testing, http.StatusPreconditionRequired, response.Code)
+	assert.Equal(testing, problem.PreconditionRequired, decodeProblem(response).Code)
+	assert.Equal(testing, http.StatusPreconditionRequired, sendAs(router, 1, http.MethodDelete, "/api/v1/projects/1", nil).Code)
+
+	assert.Equal(testing, http.StatusOK, sendAs(router, 1, http.MethodPut, "/api/v1/projects/1", map[string]string{"name": "Renamed project"}, map[string]string{"If-Match": `"1"`}).Code)
+
+	// Reading a project or changing anything else doesn't require the header.
+	assert.Equal(testing, http.StatusOK, sendAs(router, 1, http.MethodGet, "/api/v1/projects/1", nil).Code)
+	assert.Equal(testing, http.StatusOK, sendAs(router, 1, http.MethodPut, "/api/v1/projects/1/unpublish", nil).Code)
+}
+
+// concurrentProjectRepository changes the project in the database right before it is revised, as a concurrent request would.
+type concurrentProjectRepository struct {
+	repositories.ProjectRepository
+}
+
+func (repository concurrentProjectRepository) Revise(ctx context.Context, project *models.Project, updates map[string]any, revision *models.ProjectRevision) error {
+	config.DB.Model(&models.Project{}).Where("id = ?", project.ID).Update("version", gorm.Expr("version + 1"))
+
+	return repository.ProjectRepository.Revise(ctx, project, updates, revision)
+}
+
+func TestConcurrentUpdateDiscardsImage(testing *testing.T) {
+	InitTest()
+
+	directory := testing.TempDir()
+
+	request := CreateMultipartRequest(http.MethodPut, "/api/v1/projects/1", nil, true)
+	if err := request.ParseMultipartForm(1 << 20); err != nil {
+		log.Fatal("Unable to parse multipart form: ", err)
+	}
+
+	uploads := repositories.NewUploadRepository(config.DB)
+	service := services.NewProjectService(concurrentProjectRepository{repositories.NewProjectRepository(config.DB)}, services.NewUploadService(uploads, directory), time.Hour)
+
+	_, err := service.Update(context.Background(), 1, 1, nil, models.ProjectUpdateInput{}, request.MultipartForm.File["image"][0])
+
+	assert.ErrorIs(testing, err, services.ErrVersionMismatch)
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		log.Fatal("Unable to read upload directory: ", err)
+	}
+
+	assert.Empty(testing, entries)
+
+	usage, err := uploads.Usage(context.Background(), 1)
+
+	assert.NoError(testing, err)
+	assert.Zero(testing, usage.Files)
+}
+
+func TestProjectRepositoryRejectsStaleUpdate(testing *testing.T) {
+	InitTest()
+
+	projects := repositories.NewProjectRepository(config.DB)
+
+	first, err := projects.Find(context.Background(), 1)
+	if err != nil {
+		log.Fatal("Unable to find project: ", err)
+	}
+
+	second, err := projects.Find(context.Background(), 1)
+	if err != nil {
+		log.Fatal("Unable to find project: ", err)
+	}
+
+	assert.NoError(testing, projects.Update(context.Background(), first, map[string]any{"name": "First change"}))
+	assert.Equal(testing, uint(2), first.Version)
+
+	assert.ErrorIs(testing, projects.Update(context.Background(), second, map[string]any{"name": "Second change"}), repositories.ErrStale)
+	assert.ErrorIs(testing, projects.Delete(context.Background(), second), repositories.ErrStale)
+
+	var project models.Project
+	config.DB.First(&project, 1)
+
+	assert.Equal(testing, "First change", project.Name)
+	assert.Equal(testing, uint(2), project.Version)
+}
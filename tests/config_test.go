@@ -0,0 +1,59 @@
+package tests
+
+import (
+	"log"
+	"os"
+	"partage-projets/config"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(testing *testing.T) {
+	path := filepath.Join(testing.TempDir(), "config.yaml")
+
+	content := []byte("database_dsn: host=localhost\njwt_secret: yaml_secret\nport: 9000\ncors_origins:\n  - https://example.com\nupload_sweep_interval: 24h\n")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		log.Fatal("Unable to write configuration file: ", err)
+	}
+
+	testing.Setenv("JWT_SECRET", "env_secret")
+	testing.Setenv("RATE_LIMIT", "20")
+	testing.Setenv("TRASH_RETENTION", "168h")
+
+	configuration, err := config.Load(path)
+
+	assert.NoError(testing, err)
+	assert.Equal(testing, "host=localhost", configuration.DatabaseDSN)
+	assert.Equal(testing, "env_secret", configuration.JWTSecret)
+	assert.Equal(testing, 9000, configuration.Port)
+	assert.Equal(testing, []string{"https://example.com"}, configuration.CORSOrigins)
+	assert.Equal(testing, 20, configuration.RateLimit)
+	assert.Equal(testing, 24*time.Hour, configuration.UploadSweepInterval)
+	assert.Equal(testing, time.Hour, configuration.UploadSweepGracePeriod)
+	assert.Equal(testing, 7*24*time.Hour, configuration.TrashRetention)
+	assert.Zero(testing, configuration.TrashPurgeInterval)
+}
+
+func TestLoadConfigWithoutJWTSecret(testing *testing.T) {
+	testing.Setenv("DATABASE_DSN", "host=localhost")
+	testing.Setenv("JWT_SECRET", "")
+
+	_, err := config.Load("")
+
+	assert.ErrorContains(testing, err, "JWT_SECRET is required")
+}
+
+func TestLoadConfigInvalidEnvironment(testing *testing.T) {
+	testing.Setenv("DATABASE_DSN", "host=localhost")
+	testing.Setenv("JWT_SECRET", "secret")
+	testing.Setenv("PORT", "http")
+	testing.Setenv("TOKEN_DURATION", "forever")
+
+	_, err := config.Load("")
+
+	assert.ErrorContains(testing, err, "PORT must be a number")
+	assert.ErrorContains(testing, err, "TOKEN_DURATION must be a duration")
+}
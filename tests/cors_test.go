@@ -0,0 +1,99 @@
+package tests
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"partage-projets/config"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func preflight(configuration *config.Config, origin string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(config.CORSMiddleware(configuration))
+	router.GET("/api/v1/projects/", func(context *gin.Context) {
+		context.JSON(http.StatusOK, gin.H{})
+	})
+
+	request, err := http.NewRequest(http.MethodOptions, "/api/v1/projects/", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Origin", origin)
+	request.Header.Set("Access-Control-Request-Method", http.MethodPut)
+	request.Header.Set("Access-Control-Request-Headers", "Authorization")
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	return response
+}
+
+func TestCORSPreflightAllowedOrigin(testing *testing.T) {
+	configuration := NewTestConfig()
+	configuration.CORSOrigins = []string{"https://front.example.com"}
+
+	response := preflight(configuration, "https://front.example.com")
+
+	assert.Equal(testing, http.StatusNoContent, response.Code)
+	assert.Equal(testing, "https://front.example.com", response.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(testing, "true", response.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Contains(testing, response.Header().Get("Access-Control-Allow-Methods"), "PUT")
+	assert.Contains(testing, response.Header().Get("Access-Control-Allow-Headers"), "Authorization")
+}
+
+func TestCORSPreflightForbiddenOrigin(testing *testing.T) {
+	configuration := NewTestConfig()
+	configuration.CORSOrigins = []string{"https://front.example.com"}
+
+	response := preflight(configuration, "https://evil.example.org")
+
+	assert.Equal(testing, http.StatusForbidden, response.Code)
+	assert.Empty(testing, response.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORSPreflightWildcardSubdomain(testing *testing.T) {
+	configuration := NewTestConfig()
+	configuration.CORSOrigins = []string{"https://*.example.com"}
+
+	assert.Equal(testing, http.StatusNoContent, preflight(configuration, "https://preview-42.example.com").Code)
+	assert.Equal(testing, http.StatusForbidden, preflight(configuration, "https://example.com").Code)
+	assert.Equal(testing, http.StatusForbidden, preflight(configuration, "https://example.com.evil.org").Code)
+	assert.Equal(testing, http.StatusForbidden, preflight(configuration, "http://app.example.com").Code)
+}
+
+func TestCORSPreflightDevMode(testing *testing.T) {
+	configuration := NewTestConfig()
+	configuration.CORSDevMode = true
+
+	response := preflight(configuration, "http://localhost:5173")
+
+	assert.Equal(testing, http.StatusNoContent, response.Code)
+	assert.Equal(testing, "http://localhost:5173", response.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORSPreflightConfiguredMethods(testing *testing.T) {
+	configuration := NewTestConfig()
+	configuration.CORSMethods = []string{"GET"}
+	configuration.CORSCredentials = false
+
+	response := preflight(configuration, "http://localhost")
+
+	assert.Equal(testing, http.StatusNoContent, response.Code)
+	assert.Equal(testing, "GET", response.Header().Get("Access-Control-Allow-Methods"))
+	assert.Empty(testing, response.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCORSConfigRejectsWildcardWithCredentials(testing *testing.T) {
+	configuration := NewTestConfig()
+	configuration.CORSOrigins = []string{"*"}
+
+	assert.Error(testing, configuration.Validate())
+}
@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"partage-projets/config"
+	"partage-projets/controllers"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func getReadiness(router *gin.Engine) (int, controllers.Readiness) {
+	request, err := http.NewRequest(http.MethodGet, "/readyz", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	var readiness controllers.Readiness
+	if err := json.Unmarshal(response.Body.Bytes(), &readiness); err != nil {
+		log.Fatal("Unable to unmarshal response: ", err)
+	}
+
+	return response.Code, readiness
+}
+
+func TestHealthz(testing *testing.T) {
+	router := InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/healthz", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Contains(testing, response.Body.String(), "ok")
+}
+
+func TestReadyz(testing *testing.T) {
+	router, _ := InitTestWithUploads(testing)
+
+	code, readiness := getReadiness(router)
+
+	assert.Equal(testing, http.StatusOK, code)
+	assert.Equal(testing, "ok", readiness.Status)
+	assert.Equal(testing, "ok", readiness.Checks["database"].Status)
+	assert.Equal(testing, "ok", readiness.Checks["storage"].Status)
+	assert.Equal(testing, "ok", readiness.Checks["migrations"].Status)
+	assert.Equal(testing, 0, *readiness.Checks["migrations"].Pending)
+	assert.NotEmpty(testing, readiness.Checks["migrations"].Version)
+}
+
+func TestReadyzStorageNotWritable(testing *testing.T) {
+	InitTest()
+
+	path := filepath.Join(testing.TempDir(), "file")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		log.Fatal("Unable to write file: ", err)
+	}
+
+	configuration := NewTestConfig()
+	configuration.UploadDirectory = path
+
+	router := newTestRouter(config.DB, configuration)
+
+	code, readiness := getReadiness(router)
+
+	assert.Equal(testing, http.StatusServiceUnavailable, code)
+	assert.Equal(testing, "error", readiness.Status)
+	assert.Equal(testing, "error", readiness.Checks["storage"].Status)
+	assert.NotContains(testing, readiness.Checks["storage"].Error, path)
+	assert.Equal(testing, "ok", readiness.Checks["database"].Status)
+}
+
+func TestReadyzDatabaseDown(testing *testing.T) {
+	router, _ := InitTestWithUploads(testing)
+
+	sqlDB, err := config.DB.DB()
+	if err != nil {
+		log.Fatal("Unable to get database: ", err)
+	}
+
+	sqlDB.Close()
+
+	code, readiness := getReadiness(router)
+
+	assert.Equal(testing, http.StatusServiceUnavailable, code)
+	assert.Equal(testing, "error", readiness.Checks["database"].Status)
+	assert.Equal(testing, "error", readiness.Checks["migrations"].Status)
+
+	// The probe is public, the actual errors are only logged.
+	assert.Equal(testing, "database unreachable", readiness.Checks["database"].Error)
+	assert.Equal(testing, "migrations unreadable", readiness.Checks["migrations"].Error)
+}
+
+func TestGetBuildInfo(testing *testing.T) {
+	router := InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/version", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var info config.BuildInfo
+	if err := json.Unmarshal(response.Body.Bytes(), &info); err != nil {
+		log.Fatal("Unable to unmarshal response: ", err)
+	}
+
+	assert.Equal(testing, config.Version, info.Version)
+	assert.NotEmpty(testing, info.GoVersion)
+}
@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"partage-projets/i18n"
+	"partage-projets/middlewares"
+	"partage-projets/problem"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateLanguage(testing *testing.T) {
+	negotiator := i18n.NewNegotiator(i18n.English)
+
+	assert.Equal(testing, i18n.French, negotiator.Negotiate("fr-FR,fr;q=0.9,en;q=0.8"))
+	assert.Equal(testing, i18n.English, negotiator.Negotiate("de-DE,en;q=0.5"))
+	assert.Equal(testing, i18n.English, negotiator.Negotiate("de-DE"))
+	assert.Equal(testing, i18n.English, negotiator.Negotiate(""))
+
+	assert.Equal(testing, i18n.French, i18n.NewNegotiator(i18n.French).Negotiate("de-DE"))
+}
+
+func TestProblemInFrench(testing *testing.T) {
+	router := InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/projects/999", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Accept-Language", "fr-FR,fr;q=0.9")
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Equal(testing, i18n.French, response.Header().Get("Content-Language"))
+
+	body := decodeProblem(response)
+
+	assert.Equal(testing, problem.ProjectNotFound, body.Code)
+	assert.Equal(testing, "Projet introuvable.", body.Detail)
+}
+
+func TestValidationErrorsInFrench(testing *testing.T) {
+	router := InitTest()
+
+	request := newRegisterRequest(map[string]string{"password": "short"})
+	request.Header.Set("Accept-Language", "fr")
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := decodeProblem(response)
+
+	assert.Equal(testing, "Données invalides.", body.Detail)
+	assert.ElementsMatch(testing, []problem.FieldError{
+		{Field: "Email", Code: problem.FieldRequired, Detail: "Ce champ est obligatoire."},
+		{Field: "Password", Code: problem.FieldMinLength, Detail: "Ce champ doit contenir au moins 8 caractères."},
+	}, body.Errors)
+}
+
+func TestPasswordErrorInFrench(testing *testing.T) {
+	router := InitTest()
+
+	request := newRegisterRequest(map[string]string{"email": "user2@example.com", "password": "Password123"})
+	request.Header.Set("Accept-Language", "fr")
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	body := decodeProblem(response)
+
+	assert.Equal(testing, []problem.FieldError{
+		{Field: "Password", Code: problem.PasswordMissingSpecial, Detail: "Le mot de passe doit contenir au moins un caractère spécial."},
+	}, body.Errors)
+}
+
+func TestSuccessMessageInFrench(testing *testing.T) {
+	router := InitTest()
+
+	request, err := http.NewRequest(http.MethodPut, "/api/v1/projects/1/like", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Accept-Language", "fr")
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Contains(testing, response.Body.String(), "Like ajouté avec succès.")
+}
+
+func TestDefaultLanguage(testing *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middlewares.Language(i18n.French))
+	router.NoRoute(problem.NotFound)
+
+	request, err := http.NewRequest(http.MethodGet, "/unknown", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Accept-Language", "de-DE")
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, "Route introuvable.", decodeProblem(response).Detail)
+	assert.Contains(testing, response.Header().Values("Vary"), "Accept-Language")
+}
+
+func TestConfigRejectsUnsupportedLanguage(testing *testing.T) {
+	configuration := NewTestConfig()
+	configuration.DefaultLanguage = "de"
+
+	assert.ErrorContains(testing, configuration.Validate(), "DEFAULT_LANGUAGE")
+}
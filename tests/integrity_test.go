@@ -0,0 +1,109 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"partage-projets/config"
+	"partage-projets/jobs"
+	"partage-projets/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// orphanQueries count the rows referring to a project or a user that doesn't exist anymore.
+var orphanQueries = map[string]string{
+	"comments of missing projects":      "SELECT COUNT(*) FROM comments WHERE project_id NOT IN (SELECT id FROM projects)",
+	"comments of missing users":         "SELECT COUNT(*) FROM comments WHERE user_id NOT IN (SELECT id FROM users)",
+	"likes of missing projects":         "SELECT COUNT(*) FROM project_likes WHERE project_id NOT IN (SELECT id FROM projects)",
+	"likes of missing users":            "SELECT COUNT(*) FROM project_likes WHERE user_id NOT IN (SELECT id FROM users)",
+	"collaborators of missing projects": "SELECT COUNT(*) FROM project_collaborators WHERE project_id NOT IN (SELECT id FROM projects)",
+	"collaborators of missing users":    "SELECT COUNT(*) FROM project_collaborators WHERE user_id NOT IN (SELECT id FROM users)",
+	"images of missing projects":        "SELECT COUNT(*) FROM project_images WHERE project_id NOT IN (SELECT id FROM projects)",
+	"projects owned by missing users":   "SELECT COUNT(*) FROM projects WHERE owner_id NOT IN (SELECT id FROM users)",
+}
+
+func assertNoOrphans(testing *testing.T) {
+	for name, query := range orphanQueries {
+		var count int64
+
+		assert.NoError(testing, config.DB.Raw(query).Scan(&count).Error)
+		assert.Zero(testing, count, name)
+	}
+}
+
+// populateProject gives the first project a like, a collaborator, a gallery and a comment from each user.
+// It returns the upload directory holding the gallery.
+func populateProject(testing *testing.T) string {
+	router, directory := InitTestWithUploads(testing)
+
+	createTestProjectImages(directory)
+
+	assert.Equal(testing, http.StatusOK, sendAs(router, 1, http.MethodPut, "/api/v1/projects/1/collaborators/2", nil).Code)
+	assert.Equal(testing, http.StatusOK, sendAs(router, 2, http.MethodPut, "/api/v1/projects/1/like", nil).Code)
+	assert.Equal(testing, http.StatusCreated, sendAs(router, 2, http.MethodPost, "/api/v1/comments/", map[string]any{"project_id": 1, "content": "Collaborator comment"}).Code)
+
+	return directory
+}
+
+func TestPurgeLeavesNoOrphans(testing *testing.T) {
+	directory := populateProject(testing)
+
+	projects, comments := newTestTrashServices(directory)
+
+	assert.NoError(testing, projects.Delete(context.Background(), 1, 1, nil))
+	expire(&models.Project{}, 1)
+
+	_, err := jobs.PurgeTrash(context.Background(), projects, comments)
+	assert.NoError(testing, err)
+
+	var count int64
+	config.DB.Unscoped().Model(&models.Project{}).Where("id = ?", 1).Count(&count)
+
+	assert.Zero(testing, count)
+	assertNoOrphans(testing)
+}
+
+func TestDeletingProjectCascades(testing *testing.T) {
+	populateProject(testing)
+
+	assert.NoError(testing, config.DB.Exec("DELETE FROM projects WHERE id = ?", 1).Error)
+
+	for _, table := range []string{"comments", "project_likes", "project_collaborators", "project_images"} {
+		var count int64
+
+		config.DB.Table(table).Where("project_id = ?", 1).Count(&count)
+
+		assert.Zero(testing, count, table)
+	}
+
+	assertNoOrphans(testing)
+}
+
+func TestDeletingUserCascades(testing *testing.T) {
+	populateProject(testing)
+
+	assert.NoError(testing, config.DB.Exec("DELETE FROM users WHERE id = ?", 1).Error)
+
+	var comments int64
+	config.DB.Unscoped().Model(&models.Comment{}).Where("user_id = ?", 1).Count(&comments)
+
+	assert.Zero(testing, comments)
+
+	// The projects of a deleted user are kept, without an owner, along with the contributions of others.
+	var project models.Project
+	config.DB.Preload("Likes").Preload("Comments").First(&project, 1)
+
+	assert.Nil(testing, project.OwnerID)
+	assert.Len(testing, project.Likes, 1)
+	assert.Len(testing, project.Comments, 1)
+	assertNoOrphans(testing)
+}
+
+func TestForeignKeysRejectOrphans(testing *testing.T) {
+	InitTest()
+
+	assert.Error(testing, config.DB.Create(&models.Comment{ProjectID: 42, UserID: 1, Content: "Orphan"}).Error)
+	assert.Error(testing, config.DB.Create(&models.ProjectImage{ProjectID: 42, Path: "orphan.png"}).Error)
+	assert.Error(testing, config.DB.Exec("INSERT INTO project_likes (user_id, project_id) VALUES (?, ?)", 42, 1).Error)
+}
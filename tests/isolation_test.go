@@ -0,0 +1,68 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoutersWithIsolatedDatabases(testing *testing.T) {
+	gin.SetMode(gin.TestMode)
+	testing.Parallel()
+
+	names := []string{"Isolated project A", "Isolated project B"}
+	routers := make([]*gin.Engine, len(names))
+
+	var group sync.WaitGroup
+
+	for index, name := range names {
+		routers[index] = NewTestRouter(setupTestDatabase())
+
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			data, err := json.Marshal(map[string]any{"name": name, "description": "Isolated"})
+			if err != nil {
+				log.Fatal("Unable to marshal data: ", err)
+			}
+
+			request, err := http.NewRequest(http.MethodPost, "/api/v1/projects/", bytes.NewBuffer(data))
+			if err != nil {
+				log.Fatal("Unable to create request: ", err)
+			}
+
+			request.Header.Set("Content-Type", "application/json")
+
+			AuthenticateUser(request)
+
+			routers[index].ServeHTTP(httptest.NewRecorder(), request)
+		}()
+	}
+
+	group.Wait()
+
+	for index, router := range routers {
+		request, err := http.NewRequest(http.MethodGet, "/api/v1/projects/", nil)
+		if err != nil {
+			log.Fatal("Unable to create request: ", err)
+		}
+
+		AuthenticateUser(request)
+
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+
+		assert.Equal(testing, http.StatusOK, response.Code)
+		assert.Contains(testing, response.Body.String(), names[index])
+		assert.NotContains(testing, response.Body.String(), names[1-index])
+	}
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"partage-projets/logging"
+	"partage-projets/middlewares"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDGenerated(testing *testing.T) {
+	router := InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/healthz", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Regexp(testing, "^[0-9a-f]{32}$", response.Header().Get(middlewares.RequestIDHeader))
+}
+
+func TestRequestIDPropagated(testing *testing.T) {
+	router := InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/healthz", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set(middlewares.RequestIDHeader, "front-1234")
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, "front-1234", response.Header().Get(middlewares.RequestIDHeader))
+}
+
+func TestRequestIDInvalidReplaced(testing *testing.T) {
+	router := InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/healthz", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set(middlewares.RequestIDHeader, "not a valid {id}")
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Regexp(testing, "^[0-9a-f]{32}$", response.Header().Get(middlewares.RequestIDHeader))
+}
+
+func TestLogsCarryRequestAndUserID(testing *testing.T) {
+	router := InitTest()
+
+	var buffer bytes.Buffer
+	logging.Setup(&buffer, slog.LevelDebug)
+
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/projects/1", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set(middlewares.RequestIDHeader, "front-1234")
+
+	AuthenticateUser(request)
+
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	messages := map[string]map[string]interface{}{}
+
+	scanner := bufio.NewScanner(&buffer)
+	for scanner.Scan() {
+		var line map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			log.Fatal("Unable to unmarshal log line: ", err)
+		}
+
+		messages[line["msg"].(string)] = line
+	}
+
+	assert.Contains(testing, messages, "query")
+	assert.Equal(testing, "front-1234", messages["query"]["request_id"])
+	assert.Equal(testing, float64(1), messages["query"]["user_id"])
+	assert.NotEmpty(testing, messages["query"]["sql"])
+
+	assert.Contains(testing, messages, "request")
+	assert.Equal(testing, "front-1234", messages["request"]["request_id"])
+	assert.Equal(testing, float64(1), messages["request"]["user_id"])
+	assert.Equal(testing, "/api/v1/projects/:id", messages["request"]["route"])
+	assert.Equal(testing, float64(http.StatusOK), messages["request"]["status"])
+}
+
+func TestParseLevel(testing *testing.T) {
+	level, err := logging.ParseLevel("debug")
+
+	assert.NoError(testing, err)
+	assert.Equal(testing, slog.LevelDebug, level)
+
+	_, err = logging.ParseLevel("verbose")
+
+	assert.Error(testing, err)
+}
@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"partage-projets/config"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestMedia(directory string, name string, content []byte) {
+	if err := os.WriteFile(filepath.Join(directory, name), content, 0644); err != nil {
+		log.Fatal("Unable to write media: ", err)
+	}
+}
+
+func TestGetMedia(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	content := []byte("Test media content")
+	hash := sha256.Sum256(content)
+	name := hex.EncodeToString(hash[:]) + ".png"
+
+	createTestMedia(directory, name, content)
+
+	request, err := http.NewRequest(http.MethodGet, "/media/"+name, nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, "Test media content", response.Body.String())
+	assert.Equal(testing, `"`+hex.EncodeToString(hash[:])+`"`, response.Header().Get("ETag"))
+	assert.Contains(testing, response.Header().Get("Cache-Control"), "immutable")
+	assert.Equal(testing, "image/png", response.Header().Get("Content-Type"))
+}
+
+func TestGetMediaNotContentAddressed(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	createTestMedia(directory, "image.png", []byte("Test media content"))
+
+	request, err := http.NewRequest(http.MethodGet, "/media/image.png", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.NotEmpty(testing, response.Header().Get("ETag"))
+	assert.NotContains(testing, response.Header().Get("Cache-Control"), "immutable")
+}
+
+func TestGetMediaRange(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	createTestMedia(directory, "image.png", []byte("Test media content"))
+
+	request, err := http.NewRequest(http.MethodGet, "/media/image.png", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Range", "bytes=5-9")
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusPartialContent, response.Code)
+	assert.Equal(testing, "media", response.Body.String())
+	assert.Equal(testing, "bytes 5-9/18", response.Header().Get("Content-Range"))
+}
+
+func TestGetMediaNotModified(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	createTestMedia(directory, "image.png", []byte("Test media content"))
+
+	requestFirst, err := http.NewRequest(http.MethodGet, "/media/image.png", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	responseFirst := httptest.NewRecorder()
+
+	router.ServeHTTP(responseFirst, requestFirst)
+
+	requestSecond, err := http.NewRequest(http.MethodGet, "/media/image.png", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	requestSecond.Header.Set("If-None-Match", responseFirst.Header().Get("ETag"))
+
+	responseSecond := httptest.NewRecorder()
+
+	router.ServeHTTP(responseSecond, requestSecond)
+
+	assert.Equal(testing, http.StatusNotModified, responseSecond.Code)
+	assert.Empty(testing, responseSecond.Body.String())
+}
+
+func TestHeadMedia(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	createTestMedia(directory, "image.png", []byte("Test media content"))
+
+	request, err := http.NewRequest(http.MethodHead, "/media/image.png", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, "18", response.Header().Get("Content-Length"))
+	assert.Empty(testing, response.Body.String())
+}
+
+func TestGetMediaOutsideUploadDirectory(testing *testing.T) {
+	InitTest()
+
+	directory := testing.TempDir()
+	createTestMedia(directory, "image.png", []byte("Test media content"))
+
+	configuration := NewTestConfig()
+	configuration.UploadDirectory = filepath.Join(directory, "uploads")
+
+	router := newTestRouter(config.DB, configuration)
+
+	request, err := http.NewRequest(http.MethodGet, "/media/../image.png", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+}
@@ -0,0 +1,176 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"partage-projets/config"
+	"partage-projets/controllers"
+	"partage-projets/metrics"
+	"partage-projets/routes"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func scrapeMetrics(router *gin.Engine) *httptest.ResponseRecorder {
+	request, err := http.NewRequest(http.MethodGet, "/metrics", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	return response
+}
+
+func TestMetricsRequestsByRoute(testing *testing.T) {
+	router := InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/projects/1", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	AuthenticateUser(request)
+
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	response := scrapeMetrics(router)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	body := response.Body.String()
+
+	assert.Contains(testing, body, `http_requests_total{method="GET",route="/api/v1/projects/:id",status="200"}`)
+	assert.Contains(testing, body, `http_request_duration_seconds_bucket{method="GET",route="/api/v1/projects/:id",status="200",le="0.005"}`)
+	assert.NotContains(testing, body, `route="/api/v1/projects/1"`)
+}
+
+func TestMetricsDatabasePool(testing *testing.T) {
+	router := InitTest()
+
+	sqlDB, err := config.DB.DB()
+	if err != nil {
+		log.Fatal("Unable to access database pool: ", err)
+	}
+
+	assert.NoError(testing, metrics.RegisterDatabase(sqlDB))
+
+	// Registering a new pool replaces the previous one.
+	assert.NoError(testing, metrics.RegisterDatabase(sqlDB))
+
+	body := scrapeMetrics(router).Body.String()
+
+	assert.Contains(testing, body, `go_sql_open_connections{db_name="default"}`)
+}
+
+func TestMetricsDomainCounters(testing *testing.T) {
+	router := InitTest()
+
+	projects := testutil.ToFloat64(metrics.ProjectsCreated)
+	comments := testutil.ToFloat64(metrics.CommentsCreated)
+	likes := testutil.ToFloat64(metrics.ProjectLikes.WithLabelValues("like"))
+
+	data, err := json.Marshal(map[string]interface{}{
+		"name":        "Test project 3",
+		"description": "Test description 3",
+		"skills":      []string{"Go"},
+	})
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	requestProject, err := http.NewRequest(http.MethodPost, "/api/v1/projects/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	requestProject.Header.Set("Content-Type", "application/json")
+
+	AuthenticateUser(requestProject)
+
+	data, err = json.Marshal(map[string]interface{}{
+		"project_id": 1,
+		"content":    "Test comment",
+	})
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	requestComment, err := http.NewRequest(http.MethodPost, "/api/v1/comments/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	requestComment.Header.Set("Content-Type", "application/json")
+
+	AuthenticateUser(requestComment)
+
+	requestLike, err := http.NewRequest(http.MethodPut, "/api/v1/projects/1/like", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	AuthenticateUser(requestLike)
+
+	for _, request := range []*http.Request{requestProject, requestComment, requestLike} {
+		router.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	assert.Equal(testing, projects+1, testutil.ToFloat64(metrics.ProjectsCreated))
+	assert.Equal(testing, comments+1, testutil.ToFloat64(metrics.CommentsCreated))
+	assert.Equal(testing, likes+1, testutil.ToFloat64(metrics.ProjectLikes.WithLabelValues("like")))
+}
+
+func TestMetricsRateLimitRejections(testing *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(config.RateLimit(1))
+	routes.HealthRoutes(router, NewTestConfig(), controllers.NewHealthController(nil, ""))
+
+	rejections := testutil.ToFloat64(metrics.RateLimitRejections)
+
+	for range 2 {
+		request, err := http.NewRequest(http.MethodGet, "/healthz", nil)
+		if err != nil {
+			log.Fatal("Unable to create request: ", err)
+		}
+
+		router.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	assert.Equal(testing, rejections+1, testutil.ToFloat64(metrics.RateLimitRejections))
+}
+
+func TestMetricsToken(testing *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	configuration := NewTestConfig()
+	configuration.MetricsToken = "metrics_secret"
+
+	router := gin.New()
+	routes.MetricsRoutes(router, configuration)
+
+	assert.Equal(testing, http.StatusUnauthorized, scrapeMetrics(router).Code)
+
+	request, err := http.NewRequest(http.MethodGet, "/metrics", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Authorization", "Bearer metrics_secret")
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+}
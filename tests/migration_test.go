@@ -0,0 +1,162 @@
+package tests
+
+import (
+	"log"
+	"partage-projets/migrations"
+	"partage-projets/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func openEmptyDatabase() *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(testDatabaseDSN), &gorm.Config{})
+	if err != nil {
+		log.Fatal("Unable to open database: ", err)
+	}
+
+	return db
+}
+
+func TestMigrationsMatchModels(testing *testing.T) {
+	db := openEmptyDatabase()
+
+	_, err := migrations.Up(db)
+	assert.NoError(testing, err)
+
+	for _, model := range models.All() {
+		statement := &gorm.Statement{DB: db}
+		if err := statement.Parse(model); err != nil {
+			log.Fatal("Unable to parse model: ", err)
+		}
+
+		assert.True(testing, db.Migrator().HasTable(statement.Schema.Table), "missing table %s", statement.Schema.Table)
+
+		for _, field := range statement.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+
+			assert.True(testing, db.Migrator().HasColumn(statement.Schema.Table, field.DBName), "missing column %s.%s", statement.Schema.Table, field.DBName)
+		}
+
+		for _, relationship := range statement.Schema.Relationships.Many2Many {
+			joinTable := relationship.JoinTable
+
+			assert.True(testing, db.Migrator().HasTable(joinTable.Table), "missing table %s", joinTable.Table)
+
+			for _, field := range joinTable.Fields {
+				if field.DBName == "" {
+					continue
+				}
+
+				assert.True(testing, db.Migrator().HasColumn(joinTable.Table, field.DBName), "missing column %s.%s", joinTable.Table, field.DBName)
+			}
+		}
+	}
+}
+
+func TestMigrationsDown(testing *testing.T) {
+	db := openEmptyDatabase()
+
+	applied, err := migrations.Up(db)
+	assert.NoError(testing, err)
+
+	rolledBack, err := migrations.Down(db, len(applied))
+	assert.NoError(testing, err)
+	assert.Len(testing, rolledBack, len(applied))
+
+	for _, model := range models.All() {
+		assert.False(testing, db.Migrator().HasTable(model))
+	}
+
+	pending, err := migrations.Pending(db)
+	assert.NoError(testing, err)
+	assert.Equal(testing, len(applied), pending)
+
+	_, err = migrations.Up(db)
+	assert.NoError(testing, err)
+}
+
+func TestMigrationsInspect(testing *testing.T) {
+	db := openEmptyDatabase()
+
+	// Inspecting an empty database fails, without creating the migrations table.
+	_, _, err := migrations.Inspect(db)
+	assert.Error(testing, err)
+	assert.False(testing, db.Migrator().HasTable(&migrations.SchemaMigration{}))
+
+	applied, err := migrations.Up(db)
+	assert.NoError(testing, err)
+
+	version, pending, err := migrations.Inspect(db)
+	assert.NoError(testing, err)
+	assert.Equal(testing, applied[len(applied)-1].Version, version)
+	assert.Zero(testing, pending)
+
+	_, err = migrations.Down(db, 1)
+	assert.NoError(testing, err)
+
+	version, pending, err = migrations.Inspect(db)
+	assert.NoError(testing, err)
+	assert.Equal(testing, applied[len(applied)-2].Version, version)
+	assert.Equal(testing, 1, pending)
+}
+
+func TestMigrationsStatus(testing *testing.T) {
+	db := openEmptyDatabase()
+
+	_, err := migrations.Up(db)
+	assert.NoError(testing, err)
+
+	again, err := migrations.Up(db)
+	assert.NoError(testing, err)
+	assert.Empty(testing, again)
+
+	statuses, err := migrations.List(db)
+	assert.NoError(testing, err)
+
+	for _, status := range statuses {
+		assert.NotNil(testing, status.AppliedAt)
+	}
+}
+
+func TestMigrationsSameVersionsForEveryDialect(testing *testing.T) {
+	postgres, err := migrations.Load("postgres")
+	assert.NoError(testing, err)
+
+	sqlite, err := migrations.Load("sqlite")
+	assert.NoError(testing, err)
+
+	assert.Equal(testing, len(postgres), len(sqlite))
+
+	for index := range postgres {
+		assert.Equal(testing, postgres[index].Version, sqlite[index].Version)
+		assert.Equal(testing, postgres[index].Name, sqlite[index].Name)
+	}
+}
+
+func TestMigrationsRenameMediaPaths(testing *testing.T) {
+	db := openEmptyDatabase()
+
+	_, err := migrations.Up(db)
+	assert.NoError(testing, err)
+
+	_, err = migrations.Down(db, 1)
+	assert.NoError(testing, err)
+
+	db.Exec("INSERT INTO projects (name, description, image) VALUES ('Project', 'Description', '/srv/uploads/first.png'), ('Other', 'Description', '')")
+	db.Exec("INSERT INTO uploads (user_id, path, size) VALUES (1, 'uploads/first.png', 1)")
+
+	_, err = migrations.Up(db)
+	assert.NoError(testing, err)
+
+	var images, paths []string
+	db.Model(&models.Project{}).Order("id").Pluck("image", &images)
+	db.Model(&models.Upload{}).Pluck("path", &paths)
+
+	assert.Equal(testing, []string{"first.png", ""}, images)
+	assert.Equal(testing, []string{"first.png"}, paths)
+}
@@ -0,0 +1,200 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"partage-projets/middlewares"
+	"partage-projets/problem"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeProblem(response *httptest.ResponseRecorder) problem.Problem {
+	var body problem.Problem
+	if err := json.Unmarshal(response.Body.Bytes(), &body); err != nil {
+		log.Fatal("Unable to unmarshal problem: ", err)
+	}
+
+	return body
+}
+
+func newRegisterRequest(user map[string]string) *http.Request {
+	data, err := json.Marshal(user)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/users/register", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	return request
+}
+
+func postRegister(router *gin.Engine, user map[string]string) *httptest.ResponseRecorder {
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, newRegisterRequest(user))
+
+	return response
+}
+
+func TestProblemUnauthorized(testing *testing.T) {
+	router := InitTest()
+
+	request, err := http.NewRequest(http.MethodDelete, "/api/v1/projects/1", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set(middlewares.RequestIDHeader, "front-1234")
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusUnauthorized, response.Code)
+	assert.Equal(testing, problem.ContentType, response.Header().Get("Content-Type"))
+
+	body := decodeProblem(response)
+
+	assert.Equal(testing, problem.Unauthorized, body.Code)
+	assert.Equal(testing, "urn:partage-projets:problem:unauthorized", body.Type)
+	assert.Equal(testing, "Unauthorized", body.Title)
+	assert.Equal(testing, http.StatusUnauthorized, body.Status)
+	assert.Equal(testing, "/api/v1/projects/1", body.Instance)
+	assert.Equal(testing, "front-1234", body.RequestID)
+}
+
+func TestProblemTokenWithoutUserID(testing *testing.T) {
+	router := InitTest()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"exp": time.Now().Add(time.Hour).Unix()})
+
+	tokenString, err := token.SignedString([]byte(NewTestConfig().JWTSecret))
+	if err != nil {
+		log.Fatal("Unable to sign token: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/projects/1", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Authorization", "Bearer "+tokenString)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusUnauthorized, response.Code)
+	assert.Equal(testing, problem.InvalidToken, decodeProblem(response).Code)
+}
+
+func TestProblemProjectNotFound(testing *testing.T) {
+	router := InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/projects/999", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	body := decodeProblem(response)
+
+	assert.Equal(testing, problem.ProjectNotFound, body.Code)
+	assert.Equal(testing, "Project not found.", body.Detail)
+}
+
+func TestProblemRouteNotFound(testing *testing.T) {
+	router := InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/unknown", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Equal(testing, problem.RouteNotFound, decodeProblem(response).Code)
+}
+
+func TestProblemValidationFields(testing *testing.T) {
+	router := InitTest()
+
+	response := postRegister(router, map[string]string{"password": "short"})
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := decodeProblem(response)
+
+	assert.Equal(testing, problem.InvalidData, body.Code)
+	assert.ElementsMatch(testing, []problem.FieldError{
+		{Field: "Email", Code: problem.FieldRequired, Detail: "This field is required."},
+		{Field: "Password", Code: problem.FieldMinLength, Detail: "This field must contain at least 8 characters."},
+	}, body.Errors)
+}
+
+func TestProblemWeakPassword(testing *testing.T) {
+	router := InitTest()
+
+	response := postRegister(router, map[string]string{"email": "user2@example.com", "password": "password123!"})
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	body := decodeProblem(response)
+
+	assert.Equal(testing, problem.InvalidData, body.Code)
+	assert.Equal(testing, []problem.FieldError{
+		{Field: "Password", Code: problem.PasswordMissingUpper, Detail: "Password must contain at least one uppercase letter."},
+	}, body.Errors)
+}
+
+func TestProblemPanicRecovered(testing *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middlewares.RequestID())
+	router.Use(middlewares.Recovery())
+	router.GET("/panic", func(context *gin.Context) {
+		panic("boom")
+	})
+
+	request, err := http.NewRequest(http.MethodGet, "/panic", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusInternalServerError, response.Code)
+	assert.Equal(testing, problem.ContentType, response.Header().Get("Content-Type"))
+
+	body := decodeProblem(response)
+
+	assert.Equal(testing, problem.InternalError, body.Code)
+	assert.NotContains(testing, body.Detail, "boom")
+	assert.Equal(testing, response.Header().Get(middlewares.RequestIDHeader), body.RequestID)
+}
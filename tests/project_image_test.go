@@ -0,0 +1,281 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"partage-projets/config"
+	"partage-projets/models"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestProjectImages(directory string) []models.ProjectImage {
+	images := []models.ProjectImage{
+		{ProjectID: 1, Path: "first.png", Caption: "First", Position: 0, Cover: true},
+		{ProjectID: 1, Path: "second.png", Caption: "Second", Position: 1},
+		{ProjectID: 1, Path: "third.png", Caption: "Third", Position: 2},
+	}
+
+	for index := range images {
+		if err := os.WriteFile(filepath.Join(directory, string(images[index].Path)), []byte(images[index].Caption), 0644); err != nil {
+			log.Fatal("Unable to write image: ", err)
+		}
+
+		config.DB.Create(&images[index])
+	}
+
+	return images
+}
+
+func TestPostProjectImage(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	fields := map[string][]string{
+		"caption":  {"Test caption"},
+		"alt_text": {"Test alt text"},
+	}
+
+	request := CreateMultipartRequest(http.MethodPost, "/api/v1/projects/1/images", fields, true)
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var image models.ProjectImage
+	if err := json.Unmarshal(response.Body.Bytes(), &image); err != nil {
+		log.Fatal("Unable to unmarshal response: ", err)
+	}
+
+	assert.Equal(testing, "Test caption", image.Caption)
+	assert.Equal(testing, "Test alt text", image.AltText)
+	assert.Equal(testing, uint(1), image.ProjectID)
+	assert.True(testing, image.Cover)
+	assert.FileExists(testing, filepath.Join(directory, string(image.Path)))
+}
+
+func TestPostProjectImageIgnoresServerFields(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	fields := map[string][]string{
+		"Path":      {"/etc/passwd"},
+		"ProjectID": {"2"},
+		"Position":  {"10"},
+		"caption":   {"Test caption"},
+	}
+
+	request := CreateMultipartRequest(http.MethodPost, "/api/v1/projects/1/images", fields, true)
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var image models.ProjectImage
+	if err := json.Unmarshal(response.Body.Bytes(), &image); err != nil {
+		log.Fatal("Unable to unmarshal response: ", err)
+	}
+
+	assert.Equal(testing, "Test caption", image.Caption)
+	assert.Equal(testing, uint(1), image.ProjectID)
+	assert.Zero(testing, image.Position)
+	assert.FileExists(testing, filepath.Join(directory, string(image.Path)))
+}
+
+func TestPostProjectImageWithoutFile(testing *testing.T) {
+	router := InitTest()
+
+	request := CreateMultipartRequest(http.MethodPost, "/api/v1/projects/1/images", map[string][]string{"caption": {"Test caption"}}, false)
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Contains(testing, response.Body.String(), "Image is required.")
+}
+
+func TestPutProjectImagesOrder(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	images := createTestProjectImages(directory)
+
+	order := map[string]interface{}{
+		"image_ids": []uint{images[2].ID, images[0].ID, images[1].ID},
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPut, "/api/v1/projects/1/images/order", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var ordered []models.ProjectImage
+	if err := json.Unmarshal(response.Body.Bytes(), &ordered); err != nil {
+		log.Fatal("Unable to unmarshal response: ", err)
+	}
+
+	assert.Equal(testing, []string{"Third", "First", "Second"}, []string{ordered[0].Caption, ordered[1].Caption, ordered[2].Caption})
+}
+
+func TestPutProjectImagesOrderIncomplete(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	images := createTestProjectImages(directory)
+
+	order := map[string]interface{}{
+		"image_ids": []uint{images[2].ID, images[0].ID},
+	}
+
+	data, err := json.Marshal(order)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPut, "/api/v1/projects/1/images/order", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+}
+
+func TestPutProjectImageCover(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	images := createTestProjectImages(directory)
+
+	request, err := http.NewRequest(http.MethodPut, "/api/v1/projects/1/images/"+formatID(images[1].ID)+"/cover", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var covers []models.ProjectImage
+	config.DB.Where("cover = ?", true).Find(&covers)
+
+	assert.Len(testing, covers, 1)
+	assert.Equal(testing, images[1].ID, covers[0].ID)
+}
+
+func TestDeleteProjectImage(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	images := createTestProjectImages(directory)
+
+	request, err := http.NewRequest(http.MethodDelete, "/api/v1/projects/1/images/"+formatID(images[0].ID), nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Contains(testing, response.Body.String(), "Image deleted successfully.")
+	assert.NoFileExists(testing, filepath.Join(directory, string(images[0].Path)))
+
+	var next models.ProjectImage
+	config.DB.First(&next, images[1].ID)
+
+	assert.True(testing, next.Cover)
+}
+
+func TestDeleteProjectImageOutsideUploadDirectory(testing *testing.T) {
+	InitTest()
+
+	directory := testing.TempDir()
+
+	configuration := NewTestConfig()
+	configuration.UploadDirectory = filepath.Join(directory, "uploads")
+
+	router := newTestRouter(config.DB, configuration)
+
+	outside := filepath.Join(directory, "outside.png")
+	if err := os.WriteFile(outside, []byte("outside"), 0644); err != nil {
+		log.Fatal("Unable to write file: ", err)
+	}
+
+	for _, name := range []string{outside, "../outside.png"} {
+		image := models.ProjectImage{ProjectID: 1, Path: models.Media(name)}
+		config.DB.Create(&image)
+
+		sendAs(router, 1, http.MethodDelete, "/api/v1/projects/1/images/"+formatID(image.ID), nil)
+
+		assert.FileExists(testing, outside, name)
+	}
+}
+
+func TestDeleteProjectKeepsImagesInTrash(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	images := createTestProjectImages(directory)
+
+	request, err := http.NewRequest(http.MethodDelete, "/api/v1/projects/1", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var count int64
+	config.DB.Model(&models.ProjectImage{}).Count(&count)
+
+	assert.Equal(testing, int64(len(images)), count)
+
+	for _, image := range images {
+		assert.FileExists(testing, filepath.Join(directory, string(image.Path)))
+	}
+}
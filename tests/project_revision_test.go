@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"partage-projets/models"
+	"partage-projets/problem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeRevisions(response *httptest.ResponseRecorder) []models.ProjectRevision {
+	var revisions []models.ProjectRevision
+
+	if err := json.Unmarshal(response.Body.Bytes(), &revisions); err != nil {
+		log.Fatal("Unable to unmarshal response: ", err)
+	}
+
+	return revisions
+}
+
+func changedFields(revision models.ProjectRevision) []string {
+	fields := []string{}
+	for _, change := range revision.Changes {
+		fields = append(fields, change.Field)
+	}
+
+	return fields
+}
+
+func TestProjectRevisions(testing *testing.T) {
+	router := InitTest()
+
+	project := decodeProject(sendAs(router, 1, http.MethodPost, "/api/v1/projects/", map[string]any{"name": "First name", "description": "First description", "skills": []string{"Go"}}))
+	url := "/api/v1/projects/" + formatID(project.ID)
+
+	revisions := decodeRevisions(sendAs(router, 1, http.MethodGet, url+"/revisions", nil))
+
+	assert.Len(testing, revisions, 1)
+	assert.Equal(testing, []string{"description", "name", "skills", "visibility"}, changedFields(revisions[0]))
+
+	response := sendAs(router, 1, http.MethodPut, url, map[string]any{"name": "Second name", "description": "First description", "skills": []string{"Go", "SQL"}})
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	revisions = decodeRevisions(sendAs(router, 1, http.MethodGet, url+"/revisions", nil))
+
+	assert.Len(testing, revisions, 2)
+	assert.Equal(testing, uint(1), *revisions[0].UserID)
+	assert.Equal(testing, []models.Change{
+		{Field: "name", From: "First name", To: "Second name"},
+		{Field: "skills", From: []any{"Go"}, To: []any{"Go", "SQL"}},
+	}, []models.Change(revisions[0].Changes))
+
+	sendAs(router, 1, http.MethodPut, url+"/collaborators/2", nil)
+
+	response = sendAs(router, 2, http.MethodPut, url, map[string]string{"description": "Second description"})
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	revisions = decodeRevisions(sendAs(router, 2, http.MethodGet, url+"/revisions", nil))
+
+	assert.Len(testing, revisions, 3)
+	assert.Equal(testing, uint(2), *revisions[0].UserID)
+	assert.Equal(testing, []string{"description"}, changedFields(revisions[0]))
+
+	// The history requires an account, and is hidden with the project from those who can't see it.
+	assert.Equal(testing, http.StatusUnauthorized, sendAs(router, 0, http.MethodGet, url+"/revisions", nil).Code)
+	assert.Equal(testing, http.StatusNotFound, sendAs(router, 3, http.MethodGet, url+"/revisions", nil).Code)
+}
+
+func TestProjectRevisionsReservedToMembers(testing *testing.T) {
+	router := InitTest()
+
+	project := decodeProject(sendAs(router, 1, http.MethodPost, "/api/v1/projects/", map[string]any{"name": "Secret name", "description": "Secret description", "visibility": models.VisibilityPrivate}))
+	url := "/api/v1/projects/" + formatID(project.ID)
+
+	sendAs(router, 1, http.MethodPut, url, map[string]any{"name": "Public name", "description": "Public description", "visibility": models.VisibilityPublic})
+	sendAs(router, 1, http.MethodPut, url+"/publish", nil)
+
+	// The project is now public, but its history still holds the content it had while private.
+	assert.Equal(testing, http.StatusOK, sendAs(router, 2, http.MethodGet, url, nil).Code)
+
+	response := sendAs(router, 2, http.MethodGet, url+"/revisions", nil)
+
+	assert.Equal(testing, http.StatusForbidden, response.Code)
+	assert.NotContains(testing, response.Body.String(), "Secret")
+
+	sendAs(router, 1, http.MethodPut, url+"/collaborators/2", nil)
+
+	assert.Len(testing, decodeRevisions(sendAs(router, 2, http.MethodGet, url+"/revisions", nil)), 2)
+}
+
+func TestRestoreProjectRevision(testing *testing.T) {
+	router := InitTest()
+
+	project := decodeProject(sendAs(router, 1, http.MethodPost, "/api/v1/projects/", map[string]any{"name": "First name", "description": "First description", "skills": []string{"Go"}}))
+	url := "/api/v1/projects/" + formatID(project.ID)
+
+	sendAs(router, 1, http.MethodPut, url+"/collaborators/2", nil)
+	sendAs(router, 1, http.MethodPut, url, map[string]any{"name": "Second name", "skills": []string{"SQL"}})
+
+	first := decodeRevisions(sendAs(router, 1, http.MethodGet, url+"/revisions", nil))[1]
+
+	response := sendAs(router, 2, http.MethodPost, url+"/revisions/"+formatID(first.ID)+"/restore", nil)
+	assert.Equal(testing, http.StatusForbidden, response.Code)
+
+	response = sendAs(router, 1, http.MethodPost, url+"/revisions/"+formatID(first.ID)+"/restore", nil)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	restored := decodeProject(response)
+
+	assert.Equal(testing, "First name", restored.Name)
+	assert.Equal(testing, "First description", restored.Description)
+	assert.Equal(testing, []string{"Go"}, []string(restored.Skills))
+
+	revisions := decodeRevisions(sendAs(router, 1, http.MethodGet, url+"/revisions", nil))
+
+	assert.Len(testing, revisions, 3)
+	assert.Equal(testing, []string{"name", "skills"}, changedFields(revisions[0]))
+
+	// A revision can only be restored on its own project.
+	other := decodeProject(sendAs(router, 1, http.MethodPost, "/api/v1/projects/", map[string]string{"name": "Other project", "description": "Other"}))
+
+	for _, target := range []string{url + "/revisions/999/restore", "/api/v1/projects/" + formatID(other.ID) + "/revisions/" + formatID(first.ID) + "/restore"} {
+		response = sendAs(router, 1, http.MethodPost, target, nil)
+
+		assert.Equal(testing, http.StatusNotFound, response.Code)
+		assert.Equal(testing, problem.RevisionNotFound, decodeProblem(response).Code)
+	}
+}
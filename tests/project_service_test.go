@@ -0,0 +1,161 @@
+package tests
+
+import (
+	"context"
+	"partage-projets/models"
+	"partage-projets/repositories"
+	"partage-projets/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeProjectRepository keeps projects in memory, to test the services without a database.
+type fakeProjectRepository struct {
+	repositories.ProjectRepository
+	projects  map[uint]*models.Project
+	revisions []models.ProjectRevision
+}
+
+func newFakeProjectRepository(projects ...models.Project) *fakeProjectRepository {
+	repository := &fakeProjectRepository{projects: make(map[uint]*models.Project)}
+
+	for index := range projects {
+		repository.projects[projects[index].ID] = &projects[index]
+	}
+
+	return repository
+}
+
+func (repository *fakeProjectRepository) Find(ctx context.Context, id uint) (*models.Project, error) {
+	project, ok := repository.projects[id]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+
+	return project, nil
+}
+
+func (repository *fakeProjectRepository) Update(ctx context.Context, project *models.Project, updates map[string]any) error {
+	if name, ok := updates["name"]; ok {
+		project.Name = name.(string)
+	}
+
+	return nil
+}
+
+func (repository *fakeProjectRepository) Revise(ctx context.Context, project *models.Project, updates map[string]any, revision *models.ProjectRevision) error {
+	revision.ID = uint(len(repository.revisions) + 1)
+	repository.revisions = append(repository.revisions, *revision)
+
+	return repository.Update(ctx, project, updates)
+}
+
+func (repository *fakeProjectRepository) AddLike(ctx context.Context, project *models.Project, userID uint) error {
+	project.Likes = append(project.Likes, models.User{ID: userID})
+
+	return nil
+}
+
+func (repository *fakeProjectRepository) RemoveLike(ctx context.Context, project *models.Project, userID uint) error {
+	project.Likes = nil
+
+	return nil
+}
+
+func newFakeProjectService() *services.ProjectService {
+	owner := uint(1)
+	project := models.Project{ID: 1, Name: "Fake project", OwnerID: &owner, Images: []models.ProjectImage{{ID: 1}, {ID: 2}}}
+
+	return services.NewProjectService(newFakeProjectRepository(project), services.NewUploadService(nil, ""), time.Hour)
+}
+
+func TestProjectServiceFindNotFound(testing *testing.T) {
+	_, err := newFakeProjectService().Find(context.Background(), 42, nil)
+
+	assert.ErrorIs(testing, err, services.ErrProjectNotFound)
+}
+
+func TestProjectServiceUpdate(testing *testing.T) {
+	service := newFakeProjectService()
+	name := "Renamed project"
+
+	project, err := service.Update(context.Background(), 1, 1, nil, models.ProjectUpdateInput{Name: &name}, nil)
+
+	assert.NoError(testing, err)
+	assert.Equal(testing, name, project.Name)
+
+	_, err = service.Update(context.Background(), 1, 1, nil, models.ProjectUpdateInput{}, nil)
+
+	assert.ErrorIs(testing, err, services.ErrNoDataToUpdate)
+}
+
+func TestProjectServiceToggleLike(testing *testing.T) {
+	service := newFakeProjectService()
+
+	liked, err := service.ToggleLike(context.Background(), 3, 1)
+
+	assert.NoError(testing, err)
+	assert.True(testing, liked)
+
+	liked, err = service.ToggleLike(context.Background(), 3, 1)
+
+	assert.NoError(testing, err)
+	assert.False(testing, liked)
+}
+
+func TestProjectServiceReorderImagesRequiresEveryImage(testing *testing.T) {
+	service := newFakeProjectService()
+
+	_, err := service.ReorderImages(context.Background(), 1, 1, []uint{2})
+	assert.ErrorIs(testing, err, services.ErrInvalidImageOrder)
+
+	_, err = service.ReorderImages(context.Background(), 1, 1, []uint{2, 2})
+	assert.ErrorIs(testing, err, services.ErrInvalidImageOrder)
+}
+
+func TestProjectServiceHidesPrivateProjects(testing *testing.T) {
+	owner, collaborator, stranger := uint(1), uint(2), uint(3)
+
+	project := models.Project{ID: 1, OwnerID: &owner, Visibility: models.VisibilityPrivate, Collaborators: []models.User{{ID: collaborator}}}
+	service := services.NewProjectService(newFakeProjectRepository(project), services.NewUploadService(nil, ""), time.Hour)
+
+	for _, viewerID := range []*uint{&owner, &collaborator} {
+		_, err := service.Find(context.Background(), 1, viewerID)
+
+		assert.NoError(testing, err)
+	}
+
+	for _, viewerID := range []*uint{nil, &stranger} {
+		_, err := service.Find(context.Background(), 1, viewerID)
+
+		assert.ErrorIs(testing, err, services.ErrProjectNotFound)
+	}
+}
+
+func TestProjectServiceRecordsChangedFields(testing *testing.T) {
+	owner := uint(1)
+	repository := newFakeProjectRepository(models.Project{ID: 1, Name: "Fake project", Description: "Description", OwnerID: &owner})
+	service := services.NewProjectService(repository, services.NewUploadService(nil, ""), time.Hour)
+
+	name, description := "Renamed project", "Description"
+
+	_, err := service.Update(context.Background(), 1, 1, nil, models.ProjectUpdateInput{Name: &name, Description: &description}, nil)
+
+	assert.NoError(testing, err)
+	assert.Len(testing, repository.revisions, 1)
+
+	revision := repository.revisions[0]
+
+	assert.Equal(testing, uint(1), *revision.UserID)
+	assert.Equal(testing, name, revision.Name)
+	assert.Equal(testing, description, revision.Description)
+	assert.Equal(testing, []models.Change{{Field: "name", From: "Fake project", To: name}}, []models.Change(revision.Changes))
+
+	// Setting the fields to their current values doesn't record anything.
+	_, err = service.Update(context.Background(), 1, 1, nil, models.ProjectUpdateInput{Name: &name}, nil)
+
+	assert.NoError(testing, err)
+	assert.Len(testing, repository.revisions, 1)
+}
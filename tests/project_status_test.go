@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"partage-projets/models"
+	"partage-projets/problem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeProject(response *httptest.ResponseRecorder) models.Project {
+	var project models.Project
+
+	if err := json.Unmarshal(response.Body.Bytes(), &project); err != nil {
+		log.Fatal("Unable to unmarshal response: ", err)
+	}
+
+	return project
+}
+
+func decodeProjects(response *httptest.ResponseRecorder) []models.Project {
+	var projects []models.Project
+
+	if err := json.Unmarshal(response.Body.Bytes(), &projects); err != nil {
+		log.Fatal("Unable to unmarshal response: ", err)
+	}
+
+	return projects
+}
+
+func TestNewProjectIsDraft(testing *testing.T) {
+	router := InitTest()
+
+	response := sendAs(router, 1, http.MethodPost, "/api/v1/projects/", map[string]string{"name": "Draft project", "description": "Draft", "status": "published"})
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	project := decodeProject(response)
+
+	assert.Equal(testing, models.StatusDraft, project.Status)
+	assert.Nil(testing, project.PublishedAt)
+
+	url := "/api/v1/projects/" + formatID(project.ID)
+
+	for _, userID := range []uint{0, 2} {
+		assert.Equal(testing, http.StatusNotFound, sendAs(router, userID, http.MethodGet, url, nil).Code)
+		assert.NotContains(testing, sendAs(router, userID, http.MethodGet, "/api/v1/projects/", nil).Body.String(), "Draft project")
+	}
+
+	assert.Equal(testing, http.StatusOK, sendAs(router, 1, http.MethodGet, url, nil).Code)
+	assert.Contains(testing, sendAs(router, 1, http.MethodGet, "/api/v1/projects/", nil).Body.String(), "Draft project")
+}
+
+func TestProjectLifecycle(testing *testing.T) {
+	router := InitTest()
+
+	project := decodeProject(sendAs(router, 1, http.MethodPost, "/api/v1/projects/", map[string]string{"name": "Lifecycle project", "description": "Lifecycle"}))
+	url := "/api/v1/projects/" + formatID(project.ID)
+
+	response := sendAs(router, 2, http.MethodPut, url+"/publish", nil)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	response = sendAs(router, 1, http.MethodPut, url+"/archive", nil)
+	assert.Equal(testing, http.StatusConflict, response.Code)
+	assert.Equal(testing, problem.InvalidStatusChange, decodeProblem(response).Code)
+
+	response = sendAs(router, 1, http.MethodPut, url+"/publish", nil)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	published := decodeProject(response)
+
+	assert.Equal(testing, models.StatusPublished, published.Status)
+	assert.NotNil(testing, published.PublishedAt)
+	assert.Equal(testing, http.StatusOK, sendAs(router, 0, http.MethodGet, url, nil).Code)
+
+	response = sendAs(router, 2, http.MethodPut, url+"/unpublish", nil)
+	assert.Equal(testing, http.StatusForbidden, response.Code)
+
+	response = sendAs(router, 1, http.MethodPut, url+"/unpublish", nil)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, models.StatusDraft, decodeProject(response).Status)
+	assert.Nil(testing, decodeProject(response).PublishedAt)
+	assert.Equal(testing, http.StatusNotFound, sendAs(router, 0, http.MethodGet, url, nil).Code)
+
+	response = sendAs(router, 1, http.MethodPut, url+"/unpublish", nil)
+	assert.Equal(testing, http.StatusConflict, response.Code)
+}
+
+func TestArchivedProjectIsReadOnly(testing *testing.T) {
+	router := InitTest()
+
+	published := decodeProject(sendAs(router, 0, http.MethodGet, "/api/v1/projects/1", nil)).PublishedAt
+
+	response := sendAs(router, 1, http.MethodPut, "/api/v1/projects/1/archive", nil)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, models.StatusArchived, decodeProject(response).Status)
+
+	assert.Equal(testing, http.StatusOK, sendAs(router, 0, http.MethodGet, "/api/v1/projects/1", nil).Code)
+	assert.Contains(testing, sendAs(router, 0, http.MethodGet, "/api/v1/projects/", nil).Body.String(), "Test project 1")
+
+	for _, request := range []struct {
+		method string
+		url    string
+		body   any
+	}{
+		{http.MethodPut, "/api/v1/projects/1/like", nil},
+		{http.MethodPost, "/api/v1/comments/", map[string]any{"project_id": 1, "content": "Too late"}},
+		{http.MethodPut, "/api/v1/projects/1", map[string]string{"name": "Too late"}},
+		{http.MethodDelete, "/api/v1/projects/1", nil},
+		{http.MethodPut, "/api/v1/projects/1/collaborators/2", nil},
+		{http.MethodDelete, "/api/v1/projects/1/collaborators/2", nil},
+	} {
+		response = sendAs(router, 1, request.method, request.url, request.body)
+
+		assert.Equal(testing, http.StatusConflict, response.Code, request.method+" "+request.url)
+		assert.Equal(testing, problem.ProjectArchived, decodeProblem(response).Code)
+	}
+
+	assert.Equal(testing, http.StatusOK, sendAs(router, 0, http.MethodGet, "/api/v1/projects/1", nil).Code)
+
+	response = sendAs(router, 1, http.MethodPut, "/api/v1/projects/1/publish", nil)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.WithinDuration(testing, *published, *decodeProject(response).PublishedAt, time.Second)
+}
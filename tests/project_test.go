@@ -6,6 +6,9 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"partage-projets/config"
+	"partage-projets/models"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,7 +17,7 @@ import (
 func TestGetProjects(testing *testing.T) {
 	router := InitTest()
 
-	request, err := http.NewRequest(http.MethodGet, "/projects/", nil)
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/projects/", nil)
 	if err != nil {
 		log.Fatal("Unable to create request: ", err)
 	}
@@ -36,7 +39,7 @@ func TestGetProjects(testing *testing.T) {
 func TestGetProject(testing *testing.T) {
 	router := InitTest()
 
-	request, err := http.NewRequest(http.MethodGet, "/projects/1", nil)
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/projects/1", nil)
 	if err != nil {
 		log.Fatal("Unable to create request: ", err)
 	}
@@ -69,7 +72,7 @@ func TestPostProject(testing *testing.T) {
 		log.Fatal("Unable to marshal data: ", err)
 	}
 
-	request, err := http.NewRequest(http.MethodPost, "/projects/", bytes.NewBuffer(data))
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/projects/", bytes.NewBuffer(data))
 	if err != nil {
 		log.Fatal("Unable to create request: ", err)
 	}
@@ -91,6 +94,127 @@ func TestPostProject(testing *testing.T) {
 	assert.Contains(testing, body, "Testing")
 }
 
+func TestPostProjectIgnoresServerFields(testing *testing.T) {
+	router := InitTest()
+
+	response := sendAs(router, 1, http.MethodPost, "/api/v1/projects/", map[string]any{
+		"ID":          1,
+		"name":        "Forged project",
+		"description": "Description",
+		"Image":       "/media/forged.png",
+		"Images":      []map[string]any{{"Path": "forged.png"}},
+		"Comments":    []map[string]any{{"content": "Forged comment", "user_id": 2}},
+		"owner_id":    2,
+		"status":      models.StatusPublished,
+		"version":     10,
+		"deleted_at":  "2026-01-01T00:00:00Z",
+	})
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	project := decodeProject(response)
+
+	assert.NotEqual(testing, uint(1), project.ID)
+	assert.Empty(testing, project.Image)
+	assert.Empty(testing, project.Images)
+	assert.Empty(testing, project.Comments)
+	assert.Equal(testing, uint(1), *project.OwnerID)
+	assert.Equal(testing, models.StatusDraft, project.Status)
+	assert.Equal(testing, uint(1), project.Version)
+	assert.False(testing, project.DeletedAt.Valid)
+
+	var count int64
+	config.DB.Model(&models.Comment{}).Where("project_id = ?", project.ID).Count(&count)
+
+	assert.Zero(testing, count)
+}
+
+func TestPostProjectMultipartWithDataPart(testing *testing.T) {
+	router, _ := InitTestWithUploads(testing)
+
+	project := map[string]interface{}{
+		"name":        "Test project 3",
+		"description": "Test description 3",
+		"skills":      []string{"Go", "Testing"},
+	}
+
+	data, err := json.Marshal(project)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request := CreateMultipartRequest(http.MethodPost, "/api/v1/projects/", map[string][]string{"data": {string(data)}}, true)
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var created map[string]interface{}
+	if err := json.Unmarshal(response.Body.Bytes(), &created); err != nil {
+		log.Fatal("Unable to unmarshal response: ", err)
+	}
+
+	assert.Equal(testing, "Test project 3", created["Name"])
+	assert.Contains(testing, created["Skills"], "Testing")
+	assert.NotEmpty(testing, created["Image"])
+	assert.Equal(testing, http.StatusOK, sendAs(router, 0, http.MethodGet, created["Image"].(string), nil).Code)
+}
+
+func TestPostProjectMultipartWithFormFields(testing *testing.T) {
+	router, _ := InitTestWithUploads(testing)
+
+	fields := map[string][]string{
+		"name":        {"Test project 3"},
+		"description": {"Test description 3"},
+		"skills":      {"Go", "Testing"},
+	}
+
+	request := CreateMultipartRequest(http.MethodPost, "/api/v1/projects/", fields, true)
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var created map[string]interface{}
+	if err := json.Unmarshal(response.Body.Bytes(), &created); err != nil {
+		log.Fatal("Unable to unmarshal response: ", err)
+	}
+
+	assert.Equal(testing, "Test description 3", created["Description"])
+	assert.Contains(testing, created["Skills"], "Go")
+	assert.Contains(testing, created["Skills"], "Testing")
+	assert.Equal(testing, http.StatusOK, sendAs(router, 0, http.MethodGet, created["Image"].(string), nil).Code)
+}
+
+func TestPostProjectMultipartMissingFields(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	request := CreateMultipartRequest(http.MethodPost, "/api/v1/projects/", map[string][]string{"name": {"Test project 3"}}, true)
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		log.Fatal("Unable to read upload directory: ", err)
+	}
+
+	assert.Empty(testing, entries)
+}
+
 func TestPutProject(testing *testing.T) {
 	router := InitTest()
 
@@ -103,7 +227,7 @@ func TestPutProject(testing *testing.T) {
 		log.Fatal("Unable to marshal data: ", err)
 	}
 
-	request, err := http.NewRequest(http.MethodPut, "/projects/1", bytes.NewBuffer(data))
+	request, err := http.NewRequest(http.MethodPut, "/api/v1/projects/1", bytes.NewBuffer(data))
 	if err != nil {
 		log.Fatal("Unable to create request: ", err)
 	}
@@ -123,10 +247,33 @@ func TestPutProject(testing *testing.T) {
 	assert.Contains(testing, body, "Updated project")
 }
 
+func TestPutProjectMultipart(testing *testing.T) {
+	router, _ := InitTestWithUploads(testing)
+
+	request := CreateMultipartRequest(http.MethodPut, "/api/v1/projects/1", map[string][]string{"name": {"Updated project 1"}}, true)
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var updated map[string]interface{}
+	if err := json.Unmarshal(response.Body.Bytes(), &updated); err != nil {
+		log.Fatal("Unable to unmarshal response: ", err)
+	}
+
+	assert.Equal(testing, "Updated project 1", updated["Name"])
+	assert.Equal(testing, "Test description 1", updated["Description"])
+	assert.Equal(testing, http.StatusOK, sendAs(router, 0, http.MethodGet, updated["Image"].(string), nil).Code)
+}
+
 func TestDeleteProject(testing *testing.T) {
 	router := InitTest()
 
-	request, err := http.NewRequest(http.MethodDelete, "/projects/1", nil)
+	request, err := http.NewRequest(http.MethodDelete, "/api/v1/projects/1", nil)
 	if err != nil {
 		log.Fatal("Unable to create request: ", err)
 	}
@@ -148,7 +295,7 @@ func TestLikeProject(testing *testing.T) {
 	router := InitTest()
 
 	// Test like
-	requestLike, err := http.NewRequest(http.MethodPut, "/projects/1/like", nil)
+	requestLike, err := http.NewRequest(http.MethodPut, "/api/v1/projects/1/like", nil)
 	if err != nil {
 		log.Fatal("Unable to create request: ", err)
 	}
@@ -166,7 +313,7 @@ func TestLikeProject(testing *testing.T) {
 	assert.Contains(testing, bodyLike, "Project liked successfully.")
 
 	// Test unlike
-	requestUnlike, err := http.NewRequest(http.MethodPut, "/projects/1/like", nil)
+	requestUnlike, err := http.NewRequest(http.MethodPut, "/api/v1/projects/1/like", nil)
 	if err != nil {
 		log.Fatal("Unable to create request: ", err)
 	}
@@ -183,3 +330,93 @@ func TestLikeProject(testing *testing.T) {
 
 	assert.Contains(testing, bodyUnlike, "Project unliked successfully.")
 }
+
+func TestGetProjectsAnonymously(testing *testing.T) {
+	router := InitTest()
+
+	config.DB.Model(&models.Project{ID: 1}).Association("Likes").Append(&models.User{ID: 1})
+
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/projects/", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var projects []map[string]any
+	if err := json.Unmarshal(response.Body.Bytes(), &projects); err != nil {
+		log.Fatal("Unable to unmarshal response: ", err)
+	}
+
+	assert.Len(testing, projects, 2)
+	assert.Equal(testing, float64(1), projects[0]["likes_count"])
+	assert.NotContains(testing, projects[0], "liked_by_me")
+	assert.NotContains(testing, response.Body.String(), "user1@example.com")
+}
+
+func TestGetProjectLikedByMe(testing *testing.T) {
+	router := InitTest()
+
+	config.DB.Model(&models.Project{ID: 1}).Association("Likes").Append(&models.User{ID: 1})
+
+	for userID, liked := range map[uint]bool{1: true, 2: false} {
+		request, err := http.NewRequest(http.MethodGet, "/api/v1/projects/1", nil)
+		if err != nil {
+			log.Fatal("Unable to create request: ", err)
+		}
+
+		request.Header.Set("Authorization", "Bearer "+generateTestToken(userID))
+
+		response := httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+
+		assert.Equal(testing, http.StatusOK, response.Code)
+
+		var project map[string]any
+		if err := json.Unmarshal(response.Body.Bytes(), &project); err != nil {
+			log.Fatal("Unable to unmarshal response: ", err)
+		}
+
+		assert.Equal(testing, liked, project["liked_by_me"])
+	}
+}
+
+func TestGetProjectWithInvalidToken(testing *testing.T) {
+	router := InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/projects/1", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Authorization", "Bearer invalid")
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusUnauthorized, response.Code)
+}
+
+func TestPostProjectAnonymously(testing *testing.T) {
+	router := InitTest()
+
+	data, err := json.Marshal(map[string]any{"name": "Anonymous project", "description": "Anonymous"})
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/projects/", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusUnauthorized, response.Code)
+}
@@ -0,0 +1,338 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"partage-projets/config"
+	"partage-projets/models"
+	"partage-projets/repositories"
+	"partage-projets/services"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUsage(testing *testing.T) {
+	router, _ := InitTestWithUploads(testing)
+
+	requestUpload := CreateMultipartRequest(http.MethodPost, "/api/v1/projects/1/images", nil, true)
+
+	AuthenticateUser(requestUpload)
+
+	router.ServeHTTP(httptest.NewRecorder(), requestUpload)
+
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/users/me/usage", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	var usage models.Usage
+	if err := json.Unmarshal(response.Body.Bytes(), &usage); err != nil {
+		log.Fatal("Unable to unmarshal response: ", err)
+	}
+
+	assert.Equal(testing, int64(1), usage.Files)
+	assert.Positive(testing, usage.Bytes)
+	assert.Equal(testing, models.DefaultQuotaBytes, usage.MaxBytes)
+	assert.Equal(testing, models.DefaultQuotaFiles, usage.MaxFiles)
+}
+
+func TestUploadQuotaExceeded(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	config.DB.Create(&models.RoleQuota{Role: models.RoleUser, MaxBytes: 1, MaxFiles: 10})
+
+	request := CreateMultipartRequest(http.MethodPost, "/api/v1/projects/1/images", nil, true)
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusRequestEntityTooLarge, response.Code)
+	assert.Contains(testing, decodeProblem(response).Detail, "of 1 bytes used.")
+	assert.NotContains(testing, decodeProblem(response).Detail, "files")
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		log.Fatal("Unable to read upload directory: ", err)
+	}
+
+	assert.Empty(testing, entries)
+}
+
+func TestUploadQuotaExceededFiles(testing *testing.T) {
+	router, _ := InitTestWithUploads(testing)
+
+	// Only the number of files is limited, the storage being unlimited.
+	config.DB.Create(&models.RoleQuota{Role: models.RoleUser, MaxBytes: 0, MaxFiles: 1})
+	config.DB.Create(&models.Upload{UserID: 1, Path: "other.png", Size: 10})
+
+	request := CreateMultipartRequest(http.MethodPost, "/api/v1/projects/1/images", nil, true)
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusRequestEntityTooLarge, response.Code)
+	assert.Equal(testing, "Upload quota exceeded: 1 of 1 files used.", decodeProblem(response).Detail)
+}
+
+func TestUploadSameFileChargedOnce(testing *testing.T) {
+	router, _ := InitTestWithUploads(testing)
+
+	config.DB.Create(&models.RoleQuota{Role: models.RoleUser, MaxBytes: 0, MaxFiles: 1})
+
+	for range 2 {
+		request := CreateMultipartRequest(http.MethodPost, "/api/v1/projects/1/images", nil, true)
+
+		AuthenticateUser(request)
+
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		assert.Equal(testing, http.StatusCreated, response.Code)
+	}
+}
+
+// putProjectImage replaces the image of the project with a plain image of the given shade, so that each shade is a different file.
+func putProjectImage(router *gin.Engine, userID uint, url string, shade uint8) *httptest.ResponseRecorder {
+	var body bytes.Buffer
+
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile("image", "image.png")
+	if err != nil {
+		log.Fatal("Unable to create form file: ", err)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, 16, 16))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.Gray{Y: shade}), image.Point{}, draw.Src)
+
+	if err := png.Encode(part, img); err != nil {
+		log.Fatal("Unable to encode image: ", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		log.Fatal("Unable to close multipart writer: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPut, url, &body)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	request.Header.Set("Authorization", "Bearer "+generateTestToken(userID))
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	return response
+}
+
+func getUsage(router *gin.Engine) models.Usage {
+	var usage models.Usage
+
+	if err := json.Unmarshal(sendAs(router, 1, http.MethodGet, "/api/v1/users/me/usage", nil).Body.Bytes(), &usage); err != nil {
+		log.Fatal("Unable to unmarshal response: ", err)
+	}
+
+	return usage
+}
+
+func TestReplacedImagesFreeQuota(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	// The new image is stored before the previous one is released, so a replacement needs room for both.
+	config.DB.Create(&models.RoleQuota{Role: models.RoleUser, MaxBytes: 0, MaxFiles: 2})
+
+	names := []models.Media{}
+
+	for shade := range uint8(3) {
+		response := putProjectImage(router, 1, "/api/v1/projects/1", shade*100)
+		assert.Equal(testing, http.StatusOK, response.Code)
+
+		names = append(names, decodeProject(response).Image)
+	}
+
+	// Only the current image is charged, the previous ones being kept for the history of the project.
+	assert.Equal(testing, int64(1), getUsage(router).Files)
+
+	for _, name := range names {
+		assert.FileExists(testing, filepath.Join(directory, string(name)))
+	}
+
+	// Restoring the first image charges it again, which must fit in the quota.
+	config.DB.Model(&models.RoleQuota{}).Where("role = ?", models.RoleUser).Update("max_files", 1)
+
+	revisions := decodeRevisions(sendAs(router, 1, http.MethodGet, "/api/v1/projects/1/revisions", nil))
+	first := revisions[len(revisions)-1]
+
+	response := sendAs(router, 1, http.MethodPost, "/api/v1/projects/1/revisions/"+formatID(first.ID)+"/restore", nil)
+	assert.Equal(testing, http.StatusRequestEntityTooLarge, response.Code)
+	assert.Equal(testing, names[2], decodeProject(sendAs(router, 1, http.MethodGet, "/api/v1/projects/1", nil)).Image)
+
+	config.DB.Model(&models.RoleQuota{}).Where("role = ?", models.RoleUser).Update("max_files", 2)
+
+	response = sendAs(router, 1, http.MethodPost, "/api/v1/projects/1/revisions/"+formatID(first.ID)+"/restore", nil)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Equal(testing, names[0], decodeProject(response).Image)
+	assert.Equal(testing, int64(1), getUsage(router).Files)
+}
+
+func TestSharedImageReleasedPerUser(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	other := "/api/v1/projects/" + formatID(decodeProject(sendAs(router, 2, http.MethodPost, "/api/v1/projects/", map[string]string{"name": "Other project", "description": "Description"})).ID)
+
+	// Both users send the same file, which is stored once but charged to each of them.
+	shared := decodeProject(putProjectImage(router, 1, "/api/v1/projects/1", 0)).Image
+	assert.Equal(testing, shared, decodeProject(putProjectImage(router, 2, other, 0)).Image)
+
+	putProjectImage(router, 1, "/api/v1/projects/1", 100)
+
+	uploads := repositories.NewUploadRepository(config.DB)
+
+	first, err := uploads.Usage(context.Background(), 1)
+	assert.NoError(testing, err)
+
+	second, err := uploads.Usage(context.Background(), 2)
+	assert.NoError(testing, err)
+
+	// The first user only pays for their new image, while the second one still uses the shared file.
+	assert.Equal(testing, int64(1), first.Files)
+	assert.Equal(testing, int64(1), second.Files)
+	assert.FileExists(testing, filepath.Join(directory, string(shared)))
+}
+
+// failingProjectRepository fails to store any project or gallery image, as a database error would.
+type failingProjectRepository struct {
+	repositories.ProjectRepository
+}
+
+func (repository failingProjectRepository) Create(ctx context.Context, project *models.Project) error {
+	return errors.New("unable to create project")
+}
+
+func (repository failingProjectRepository) CreateImage(ctx context.Context, image *models.ProjectImage) error {
+	return errors.New("unable to create image")
+}
+
+func TestFailedInsertDiscardsImage(testing *testing.T) {
+	InitTest()
+
+	directory := testing.TempDir()
+
+	request := CreateMultipartRequest(http.MethodPost, "/api/v1/projects/1/images", nil, true)
+	if err := request.ParseMultipartForm(1 << 20); err != nil {
+		log.Fatal("Unable to parse multipart form: ", err)
+	}
+
+	file := request.MultipartForm.File["image"][0]
+	uploads := repositories.NewUploadRepository(config.DB)
+	service := services.NewProjectService(failingProjectRepository{repositories.NewProjectRepository(config.DB)}, services.NewUploadService(uploads, directory), time.Hour)
+
+	_, err := service.Create(context.Background(), 1, models.ProjectCreateInput{Name: "Project", Description: "Description"}, file)
+	assert.Error(testing, err)
+
+	_, err = service.AddImage(context.Background(), 1, 1, models.ProjectImageInput{}, file)
+	assert.Error(testing, err)
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		log.Fatal("Unable to read upload directory: ", err)
+	}
+
+	assert.Empty(testing, entries)
+
+	usage, err := uploads.Usage(context.Background(), 1)
+
+	assert.NoError(testing, err)
+	assert.Zero(testing, usage.Files)
+}
+
+func TestPutQuota(testing *testing.T) {
+	router := InitTest()
+
+	quota := map[string]interface{}{
+		"max_bytes": 1024,
+		"max_files": 5,
+	}
+
+	data, err := json.Marshal(quota)
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPut, "/api/v1/admin/quotas/user", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	AuthenticateAdmin(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	saved, err := repositories.NewUploadRepository(config.DB).FindQuota(context.Background(), models.RoleUser)
+
+	assert.NoError(testing, err)
+	assert.Equal(testing, int64(1024), saved.MaxBytes)
+	assert.Equal(testing, int64(5), saved.MaxFiles)
+}
+
+func TestPutQuotaForbidden(testing *testing.T) {
+	router := InitTest()
+
+	data, err := json.Marshal(map[string]interface{}{"max_bytes": 0, "max_files": 0})
+	if err != nil {
+		log.Fatal("Unable to marshal data: ", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPut, "/api/v1/admin/quotas/user", bytes.NewBuffer(data))
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusForbidden, response.Code)
+}
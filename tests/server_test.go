@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"context"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"partage-projets/commands"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func startTestServer(handler http.HandlerFunc, shutdownTimeout time.Duration) (string, context.CancelFunc, <-chan error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		log.Fatal("Unable to listen: ", err)
+	}
+
+	server := commands.NewServer(NewTestConfig(), handler)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+
+	go func() {
+		result <- commands.Serve(ctx, server, listener, shutdownTimeout)
+	}()
+
+	return "http://" + listener.Addr().String(), cancel, result
+}
+
+func TestServeDrainsInFlightRequests(testing *testing.T) {
+	started := make(chan struct{})
+
+	url, shutdown, result := startTestServer(func(writer http.ResponseWriter, request *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		_, _ = writer.Write([]byte("done"))
+	}, time.Second)
+
+	responses := make(chan string, 1)
+
+	go func() {
+		response, err := http.Get(url)
+		if err != nil {
+			responses <- err.Error()
+
+			return
+		}
+		defer response.Body.Close()
+
+		body, _ := io.ReadAll(response.Body)
+		responses <- string(body)
+	}()
+
+	<-started
+	shutdown()
+
+	assert.Equal(testing, "done", <-responses)
+	assert.NoError(testing, <-result)
+
+	_, err := http.Get(url)
+	assert.Error(testing, err)
+}
+
+func TestServeShutdownTimeout(testing *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	url, shutdown, result := startTestServer(func(writer http.ResponseWriter, request *http.Request) {
+		close(started)
+		<-release
+	}, 50*time.Millisecond)
+
+	go func() {
+		response, err := http.Get(url)
+		if err == nil {
+			response.Body.Close()
+		}
+	}()
+
+	<-started
+	shutdown()
+
+	assert.ErrorIs(testing, <-result, context.DeadlineExceeded)
+}
+
+func TestServerTimeouts(testing *testing.T) {
+	configuration := NewTestConfig()
+	configuration.ReadTimeout = 3 * time.Second
+	configuration.WriteTimeout = 4 * time.Second
+	configuration.Port = 9090
+
+	server := commands.NewServer(configuration, http.NotFoundHandler())
+
+	assert.Equal(testing, ":9090", server.Addr)
+	assert.Equal(testing, 3*time.Second, server.ReadTimeout)
+	assert.Equal(testing, 4*time.Second, server.WriteTimeout)
+	assert.Equal(testing, configuration.IdleTimeout, server.IdleTimeout)
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"partage-projets/logging"
+	"partage-projets/tracing"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+	parentSpanID = "00f067aa0ba902b7"
+)
+
+func TestTracingSpans(testing *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	router := InitTest()
+
+	var buffer bytes.Buffer
+	logging.Setup(&buffer, slog.LevelInfo)
+
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/projects/1", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("traceparent", "00-"+traceID+"-"+parentSpanID+"-01")
+
+	AuthenticateUser(request)
+
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	var server sdktrace.ReadOnlySpan
+	var queries []sdktrace.ReadOnlySpan
+
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID().String() != traceID {
+			continue
+		}
+
+		switch span.SpanKind() {
+		case trace.SpanKindServer:
+			server = span
+		case trace.SpanKindClient:
+			queries = append(queries, span)
+		}
+	}
+
+	if !assert.NotNil(testing, server) {
+		return
+	}
+
+	assert.Equal(testing, "GET /api/v1/projects/:id", server.Name())
+	assert.Equal(testing, parentSpanID, server.Parent().SpanID().String())
+	assert.True(testing, server.Parent().IsRemote())
+
+	// Preloads are traced as children of the query that triggers them.
+	names := map[string]trace.SpanID{}
+	for _, query := range queries {
+		names[query.Name()] = query.Parent().SpanID()
+	}
+
+	assert.Contains(testing, names, "select projects")
+	assert.Equal(testing, server.SpanContext().SpanID(), names["select projects"])
+
+	var line map[string]interface{}
+
+	scanner := bufio.NewScanner(&buffer)
+	for scanner.Scan() {
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			log.Fatal("Unable to unmarshal log line: ", err)
+		}
+
+		if line["msg"] == "request" {
+			break
+		}
+	}
+
+	assert.Equal(testing, "request", line["msg"])
+	assert.Equal(testing, traceID, line["trace_id"])
+	assert.Equal(testing, server.SpanContext().SpanID().String(), line["span_id"])
+}
+
+func TestTracingSetupWithoutExporter(testing *testing.T) {
+	shutdown, err := tracing.Setup(context.Background(), "none", "", 1)
+
+	assert.NoError(testing, err)
+	assert.NoError(testing, shutdown(context.Background()))
+}
+
+func TestConfigRejectsUnknownTracingExporter(testing *testing.T) {
+	configuration := NewTestConfig()
+	configuration.TracingExporter = "jaeger"
+
+	assert.ErrorContains(testing, configuration.Validate(), "TRACING_EXPORTER")
+
+	configuration.TracingExporter = "otlp"
+	configuration.TracingSampleRatio = 2
+
+	assert.ErrorContains(testing, configuration.Validate(), "TRACING_SAMPLE_RATIO")
+}
@@ -0,0 +1,198 @@
+package tests
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"partage-projets/config"
+	"partage-projets/jobs"
+	"partage-projets/models"
+	"partage-projets/problem"
+	"partage-projets/repositories"
+	"partage-projets/services"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTrashServices(directory string) (*services.ProjectService, *services.CommentService) {
+	configuration := NewTestConfig()
+	projectRepository := repositories.NewProjectRepository(config.DB)
+	uploadService := services.NewUploadService(repositories.NewUploadRepository(config.DB), directory)
+
+	projects := services.NewProjectService(projectRepository, uploadService, configuration.TrashRetention)
+	comments := services.NewCommentService(repositories.NewCommentRepository(config.DB), projectRepository, configuration.TrashRetention)
+
+	return projects, comments
+}
+
+// expire moves the deletion of the rows back beyond the trash retention.
+func expire(model any, id uint) {
+	deletedAt := time.Now().Add(-NewTestConfig().TrashRetention - time.Hour)
+
+	if err := config.DB.Unscoped().Model(model).Where("id = ?", id).Update("deleted_at", deletedAt).Error; err != nil {
+		log.Fatal("Unable to expire row: ", err)
+	}
+}
+
+func TestDeleteAndRestoreProject(testing *testing.T) {
+	router := InitTest()
+
+	response := sendAs(router, 1, http.MethodDelete, "/api/v1/projects/1", nil)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	assert.Equal(testing, http.StatusNotFound, sendAs(router, 1, http.MethodGet, "/api/v1/projects/1", nil).Code)
+	assert.NotContains(testing, sendAs(router, 1, http.MethodGet, "/api/v1/projects/", nil).Body.String(), "Test project 1")
+
+	response = sendAs(router, 1, http.MethodGet, "/api/v1/users/me/trash", nil)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	trash := decodeProjects(response)
+
+	assert.Len(testing, trash, 1)
+	assert.Equal(testing, uint(1), trash[0].ID)
+	assert.True(testing, trash[0].DeletedAt.Valid)
+
+	assert.Empty(testing, decodeProjects(sendAs(router, 2, http.MethodGet, "/api/v1/users/me/trash", nil)))
+	assert.Equal(testing, http.StatusNotFound, sendAs(router, 2, http.MethodPost, "/api/v1/projects/1/restore", nil).Code)
+
+	response = sendAs(router, 1, http.MethodPost, "/api/v1/projects/1/restore", nil)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.False(testing, decodeProject(response).DeletedAt.Valid)
+
+	response = sendAs(router, 0, http.MethodGet, "/api/v1/projects/1", nil)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Len(testing, decodeProject(response).Comments, 1)
+
+	assert.Empty(testing, decodeProjects(sendAs(router, 1, http.MethodGet, "/api/v1/users/me/trash", nil)))
+	assert.Equal(testing, http.StatusNotFound, sendAs(router, 1, http.MethodPost, "/api/v1/projects/1/restore", nil).Code)
+}
+
+func TestOnlyOwnerCanTrashAndRestoreProject(testing *testing.T) {
+	router := InitTest()
+
+	// Project 2 has no owner, so nobody can move it to the trash, where nobody could restore it.
+	response := sendAs(router, 1, http.MethodDelete, "/api/v1/projects/2", nil)
+	assert.Equal(testing, http.StatusForbidden, response.Code)
+
+	sendAs(router, 1, http.MethodPut, "/api/v1/projects/1/collaborators/2", nil)
+
+	assert.Equal(testing, http.StatusForbidden, sendAs(router, 2, http.MethodDelete, "/api/v1/projects/1", nil).Code)
+	assert.Equal(testing, http.StatusOK, sendAs(router, 1, http.MethodDelete, "/api/v1/projects/1", nil).Code)
+
+	// The collaborator neither sees the project in their trash nor can restore it.
+	assert.Empty(testing, decodeProjects(sendAs(router, 2, http.MethodGet, "/api/v1/users/me/trash", nil)))
+
+	response = sendAs(router, 2, http.MethodPost, "/api/v1/projects/1/restore", nil)
+	assert.Equal(testing, http.StatusForbidden, response.Code)
+	assert.Equal(testing, problem.Forbidden, decodeProblem(response).Code)
+
+	assert.Equal(testing, http.StatusOK, sendAs(router, 1, http.MethodPost, "/api/v1/projects/1/restore", nil).Code)
+}
+
+func TestRestoreExpiredProject(testing *testing.T) {
+	router := InitTest()
+
+	sendAs(router, 1, http.MethodDelete, "/api/v1/projects/1", nil)
+	expire(&models.Project{}, 1)
+
+	response := sendAs(router, 1, http.MethodPost, "/api/v1/projects/1/restore", nil)
+
+	assert.Equal(testing, http.StatusGone, response.Code)
+	assert.Equal(testing, problem.RestoreExpired, decodeProblem(response).Code)
+}
+
+func TestPurgeTrash(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	images := createTestProjectImages(directory)
+
+	sendAs(router, 1, http.MethodPut, "/api/v1/projects/1/like", nil)
+	sendAs(router, 1, http.MethodDelete, "/api/v1/projects/1", nil)
+	recent := decodeProject(sendAs(router, 1, http.MethodPost, "/api/v1/projects/", map[string]string{"name": "Recent project", "description": "Recent"}))
+	sendAs(router, 1, http.MethodDelete, "/api/v1/projects/"+formatID(recent.ID), nil)
+	expire(&models.Project{}, 1)
+
+	projects, comments := newTestTrashServices(directory)
+
+	report, err := jobs.PurgeTrash(context.Background(), projects, comments)
+
+	assert.NoError(testing, err)
+	assert.Equal(testing, []uint{1}, report.Projects)
+
+	var remaining, projectComments, projectImages, likes int64
+	config.DB.Unscoped().Model(&models.Project{}).Where("id = ?", 1).Count(&remaining)
+	config.DB.Unscoped().Model(&models.Comment{}).Where("project_id = ?", 1).Count(&projectComments)
+	config.DB.Model(&models.ProjectImage{}).Where("project_id = ?", 1).Count(&projectImages)
+	config.DB.Table("project_likes").Where("project_id = ?", 1).Count(&likes)
+
+	assert.Zero(testing, remaining)
+	assert.Zero(testing, projectComments)
+	assert.Zero(testing, projectImages)
+	assert.Zero(testing, likes)
+
+	for _, image := range images {
+		assert.NoFileExists(testing, filepath.Join(directory, string(image.Path)))
+	}
+
+	// The project deleted within the retention can still be restored.
+	assert.Equal(testing, http.StatusOK, sendAs(router, 1, http.MethodPost, "/api/v1/projects/"+formatID(recent.ID)+"/restore", nil).Code)
+}
+
+func TestPurgeTrashKeepsFilesOutsideUploadDirectory(testing *testing.T) {
+	router := InitTest()
+
+	parent := testing.TempDir()
+	outside := filepath.Join(parent, "outside.png")
+	if err := os.WriteFile(outside, []byte("outside"), 0644); err != nil {
+		log.Fatal("Unable to write file: ", err)
+	}
+
+	config.DB.Model(&models.Project{}).Where("id = ?", 1).Update("image", "../outside.png")
+	config.DB.Create(&models.ProjectImage{ProjectID: 1, Path: models.Media(outside)})
+	config.DB.Create(&models.ProjectRevision{ProjectID: 1, Image: "../outside.png"})
+
+	sendAs(router, 1, http.MethodDelete, "/api/v1/projects/1", nil)
+	expire(&models.Project{}, 1)
+
+	projects, comments := newTestTrashServices(filepath.Join(parent, "uploads"))
+
+	report, err := jobs.PurgeTrash(context.Background(), projects, comments)
+
+	assert.NoError(testing, err)
+	assert.Equal(testing, []uint{1}, report.Projects)
+	assert.FileExists(testing, outside)
+}
+
+func TestDeleteComment(testing *testing.T) {
+	router := InitTest()
+
+	assert.Equal(testing, http.StatusForbidden, sendAs(router, 2, http.MethodDelete, "/api/v1/comments/1", nil).Code)
+
+	response := sendAs(router, 1, http.MethodDelete, "/api/v1/comments/1", nil)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Contains(testing, response.Body.String(), "Comment deleted successfully.")
+
+	assert.Empty(testing, decodeProject(sendAs(router, 1, http.MethodGet, "/api/v1/projects/1", nil)).Comments)
+
+	response = sendAs(router, 1, http.MethodDelete, "/api/v1/comments/1", nil)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Equal(testing, problem.CommentNotFound, decodeProblem(response).Code)
+
+	expire(&models.Comment{}, 1)
+
+	projects, comments := newTestTrashServices(testing.TempDir())
+
+	report, err := jobs.PurgeTrash(context.Background(), projects, comments)
+
+	assert.NoError(testing, err)
+	assert.Equal(testing, int64(1), report.Comments)
+
+	var count int64
+	config.DB.Unscoped().Model(&models.Comment{}).Count(&count)
+
+	assert.Zero(testing, count)
+}
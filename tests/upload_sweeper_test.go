@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"context"
+	"log"
+	"os"
+	"partage-projets/config"
+	"partage-projets/jobs"
+	"partage-projets/models"
+	"partage-projets/repositories"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestUploads(directory string) (referenced string, orphan string, recent string) {
+	referenced, orphan, recent = "referenced.png", "orphan.png", "recent.png"
+
+	for _, name := range []string{referenced, orphan, recent} {
+		if err := os.WriteFile(filepath.Join(directory, name), []byte(name), 0644); err != nil {
+			log.Fatal("Unable to write upload: ", err)
+		}
+	}
+
+	old := time.Now().Add(-2 * time.Hour)
+	for _, name := range []string{referenced, orphan} {
+		if err := os.Chtimes(filepath.Join(directory, name), old, old); err != nil {
+			log.Fatal("Unable to change upload times: ", err)
+		}
+	}
+
+	config.DB.Model(&models.Project{}).Where("id = ?", 1).Update("image", referenced)
+
+	return referenced, orphan, recent
+}
+
+func TestSweepUploadsDryRun(testing *testing.T) {
+	InitTest()
+
+	directory := testing.TempDir()
+	referenced, orphan, recent := createTestUploads(directory)
+
+	report, err := jobs.SweepUploads(context.Background(), repositories.NewUploadRepository(config.DB), directory, true, time.Hour)
+
+	assert.NoError(testing, err)
+	assert.Equal(testing, 3, report.Scanned)
+	assert.Equal(testing, []string{orphan}, report.Orphans)
+	assert.Equal(testing, []string{recent}, report.Recent)
+	assert.Empty(testing, report.Removed)
+	assert.FileExists(testing, filepath.Join(directory, referenced))
+	assert.FileExists(testing, filepath.Join(directory, orphan))
+	assert.FileExists(testing, filepath.Join(directory, recent))
+}
+
+func TestSweepUploads(testing *testing.T) {
+	InitTest()
+
+	directory := testing.TempDir()
+	referenced, orphan, recent := createTestUploads(directory)
+
+	report, err := jobs.SweepUploads(context.Background(), repositories.NewUploadRepository(config.DB), directory, false, time.Hour)
+
+	assert.NoError(testing, err)
+	assert.Equal(testing, []string{orphan}, report.Removed)
+	assert.FileExists(testing, filepath.Join(directory, referenced))
+	assert.NoFileExists(testing, filepath.Join(directory, orphan))
+	assert.FileExists(testing, filepath.Join(directory, recent))
+}
+
+func TestSweepUploadsKeepsGalleryImages(testing *testing.T) {
+	InitTest()
+
+	directory := testing.TempDir()
+	_, orphan, _ := createTestUploads(directory)
+
+	config.DB.Create(&models.ProjectImage{ProjectID: 2, Path: models.Media(orphan)})
+
+	report, err := jobs.SweepUploads(context.Background(), repositories.NewUploadRepository(config.DB), directory, false, time.Hour)
+
+	assert.NoError(testing, err)
+	assert.Empty(testing, report.Removed)
+	assert.FileExists(testing, filepath.Join(directory, orphan))
+}
+
+func TestSweepUploadsKeepsRevisionImages(testing *testing.T) {
+	InitTest()
+
+	directory := testing.TempDir()
+	_, orphan, _ := createTestUploads(directory)
+
+	config.DB.Create(&models.ProjectRevision{ProjectID: 1, Image: models.Media(orphan)})
+
+	report, err := jobs.SweepUploads(context.Background(), repositories.NewUploadRepository(config.DB), directory, false, time.Hour)
+
+	assert.NoError(testing, err)
+	assert.Empty(testing, report.Removed)
+	assert.FileExists(testing, filepath.Join(directory, orphan))
+}
+
+func TestUploadSweeperStops(testing *testing.T) {
+	InitTest()
+
+	directory := testing.TempDir()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := jobs.StartUploadSweeper(ctx, repositories.NewUploadRepository(config.DB), directory, time.Millisecond, time.Hour)
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		testing.Fatal("Upload sweeper did not stop.")
+	}
+}
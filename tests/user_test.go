@@ -24,7 +24,7 @@ func TestRegister(testing *testing.T) {
 		log.Fatal("Unable to marshal data: ", err)
 	}
 
-	request, err := http.NewRequest(http.MethodPost, "/users/register", bytes.NewBuffer(data))
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/users/register", bytes.NewBuffer(data))
 	if err != nil {
 		log.Fatal("Unable to create request: ", err)
 	}
@@ -55,7 +55,7 @@ func TestRegisterExistingEmail(testing *testing.T) {
 		log.Fatal("Unable to marshal data: ", err)
 	}
 
-	request, err := http.NewRequest(http.MethodPost, "/users/register", bytes.NewBuffer(data))
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/users/register", bytes.NewBuffer(data))
 	if err != nil {
 		log.Fatal("Unable to create request: ", err)
 	}
@@ -86,7 +86,7 @@ func TestLoginSuccess(testing *testing.T) {
 		log.Fatal("Unable to marshal data: ", err)
 	}
 
-	request, err := http.NewRequest(http.MethodPost, "/users/login", bytes.NewBuffer(data))
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/users/login", bytes.NewBuffer(data))
 	if err != nil {
 		log.Fatal("Unable to create request: ", err)
 	}
@@ -117,7 +117,7 @@ func TestLoginInvalidPassword(testing *testing.T) {
 		log.Fatal("Unable to marshal data: ", err)
 	}
 
-	request, err := http.NewRequest(http.MethodPost, "/users/login", bytes.NewBuffer(data))
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/users/login", bytes.NewBuffer(data))
 	if err != nil {
 		log.Fatal("Unable to create request: ", err)
 	}
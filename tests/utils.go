@@ -1,30 +1,77 @@
 package tests
 
 import (
+	"bytes"
+	"image"
+	"image/png"
+	"io"
 	"log"
+	"log/slog"
+	"mime/multipart"
 	"net/http"
-	"os"
 	"partage-projets/config"
-	"partage-projets/models"
+	"partage-projets/logging"
+	"partage-projets/middlewares"
+	"partage-projets/migrations"
+	"partage-projets/problem"
 	"partage-projets/routes"
+	"partage-projets/seeds"
+	"partage-projets/tracing"
+	"strconv"
+	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
+// testDatabaseDSN enforces the foreign keys, which SQLite ignores by default unlike Postgres.
+const testDatabaseDSN = ":memory:?_foreign_keys=on"
+
+func NewTestConfig() *config.Config {
+	configuration := config.Default()
+	configuration.DatabaseDSN = ":memory:"
+	configuration.JWTSecret = "test_secret"
+
+	return configuration
+}
+
 func InitTest() *gin.Engine {
 	gin.SetMode(gin.TestMode)
+	logging.Setup(io.Discard, slog.LevelInfo)
 	config.DB = setupTestDatabase()
 
-	router := gin.Default()
+	return NewTestRouter(config.DB)
+}
+
+// InitTestWithUploads is InitTest storing the uploads in a temporary directory of the test, which it returns.
+func InitTestWithUploads(testing *testing.T) (*gin.Engine, string) {
+	InitTest()
+
+	configuration := NewTestConfig()
+	configuration.UploadDirectory = testing.TempDir()
+
+	return newTestRouter(config.DB, configuration), configuration.UploadDirectory
+}
+
+// NewTestRouter builds a router on top of the given database, without touching any global state.
+func NewTestRouter(db *gorm.DB) *gin.Engine {
+	return newTestRouter(db, NewTestConfig())
+}
+
+func newTestRouter(db *gorm.DB, configuration *config.Config) *gin.Engine {
+	router := gin.New()
+	router.Use(middlewares.RequestID())
+	router.Use(middlewares.Language(configuration.DefaultLanguage))
+	router.Use(middlewares.Tracing())
+	router.Use(middlewares.Metrics())
+	router.Use(middlewares.Logger())
+	router.Use(middlewares.Recovery())
 
-	routes.ProjectRoutes(router)
-	routes.UserRoutes(router)
-	routes.CommentRoutes(router)
+	routes.Register(router, configuration, db)
+	router.NoRoute(problem.NotFound)
 
 	return router
 }
@@ -35,56 +82,35 @@ func AuthenticateUser(request *http.Request) {
 	request.Header.Set("Authorization", "Bearer "+token)
 }
 
+func AuthenticateAdmin(request *http.Request) {
+	token := generateTestToken(2)
+
+	request.Header.Set("Authorization", "Bearer "+token)
+}
+
 func setupTestDatabase() *gorm.DB {
-	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db, err := gorm.Open(sqlite.Open(testDatabaseDSN), &gorm.Config{Logger: logging.NewGormLogger()})
 	if err != nil {
 		log.Fatal("Unable to setup database: ", err)
 	}
 
-	err = db.AutoMigrate(&models.Project{}, &models.User{}, &models.Comment{})
-	if err != nil {
-		log.Fatal("Unable to migrate database: ", err)
+	if err := db.Use(tracing.NewGormPlugin()); err != nil {
+		log.Fatal("Unable to trace database queries: ", err)
 	}
 
-	project1 := models.Project{
-		Name:        "Test project 1",
-		Description: "Test description 1",
-	}
-	db.Create(&project1)
-
-	project2 := models.Project{
-		Name:        "Test project 2",
-		Description: "Test description 2",
-	}
-	db.Create(&project2)
-
-	user := models.User{
-		Email:    "user1@example.com",
-		Password: "Password123!",
-	}
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	_, err = migrations.Up(db)
 	if err != nil {
-		log.Fatal("Unable to hash password: ", err)
+		log.Fatal("Unable to migrate database: ", err)
 	}
 
-	user.Password = string(hashedPassword)
-	db.Create(&user)
-
-	comment := models.Comment{
-		ProjectID: project1.ID,
-		Content:   "Test comment on project 1",
+	if err := seeds.Demo(db); err != nil {
+		log.Fatal("Unable to seed database: ", err)
 	}
-	db.Create(&comment)
 
 	return db
 }
 
 func generateTestToken(userID uint) string {
-	err := os.Setenv("JWT_SECRET", "test_secret")
-	if err != nil {
-		log.Fatal("Unable to set JWT_SECRET environment variable: ", err)
-	}
-
 	claims := jwt.MapClaims{
 		"UserID": float64(userID),
 		"exp":    time.Now().Add(time.Hour).Unix(),
@@ -92,7 +118,49 @@ func generateTestToken(userID uint) string {
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
-	tokenString, _ := token.SignedString([]byte("test_secret"))
+	tokenString, _ := token.SignedString([]byte(NewTestConfig().JWTSecret))
 
 	return tokenString
 }
+
+func CreateMultipartRequest(method string, url string, fields map[string][]string, withImage bool) *http.Request {
+	var body bytes.Buffer
+
+	writer := multipart.NewWriter(&body)
+
+	for name, values := range fields {
+		for _, value := range values {
+			if err := writer.WriteField(name, value); err != nil {
+				log.Fatal("Unable to write field: ", err)
+			}
+		}
+	}
+
+	if withImage {
+		part, err := writer.CreateFormFile("image", "image.png")
+		if err != nil {
+			log.Fatal("Unable to create form file: ", err)
+		}
+
+		if err := png.Encode(part, image.NewRGBA(image.Rect(0, 0, 16, 16))); err != nil {
+			log.Fatal("Unable to encode image: ", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		log.Fatal("Unable to close multipart writer: ", err)
+	}
+
+	request, err := http.NewRequest(method, url, &body)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return request
+}
+
+func formatID(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
@@ -0,0 +1,86 @@
+package tests
+
+import (
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"partage-projets/routes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionedRoutes(testing *testing.T) {
+	router := InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/api/v1/projects/1", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Empty(testing, response.Header().Get("Deprecation"))
+	assert.Empty(testing, response.Header().Get("Sunset"))
+}
+
+func TestLegacyRoutesAreDeprecated(testing *testing.T) {
+	router := InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/projects/1", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	AuthenticateUser(request)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Contains(testing, response.Body.String(), "Test project 1")
+	assert.Equal(testing, "@1792368000", response.Header().Get("Deprecation"))
+	assert.Equal(testing, routes.LegacySunset.Format(http.TimeFormat), response.Header().Get("Sunset"))
+	assert.Equal(testing, `</api/v1/projects/1>; rel="successor-version"`, response.Header().Get("Link"))
+}
+
+func TestInfrastructureRoutesAreNotVersioned(testing *testing.T) {
+	router := InitTest()
+
+	request, err := http.NewRequest(http.MethodGet, "/healthz", nil)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Empty(testing, response.Header().Get("Deprecation"))
+}
+
+func TestSwaggerPerVersion(testing *testing.T) {
+	router := InitTest()
+	routes.SwaggerRoutes(router)
+
+	// The swagger handler reads RequestURI, which only httptest.NewRequest fills in.
+	request := httptest.NewRequest(http.MethodGet, "/swagger/v1/doc.json", nil)
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Contains(testing, response.Body.String(), `"/api/v1/projects/{id}"`)
+
+	request = httptest.NewRequest(http.MethodGet, "/swagger/index.html", nil)
+
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusMovedPermanently, response.Code)
+	assert.Equal(testing, "/swagger/v1/index.html", response.Header().Get("Location"))
+}
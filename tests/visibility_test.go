@@ -0,0 +1,191 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"partage-projets/problem"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// sendAs sends the request with the token of the user, or anonymously for user 0, along with the given headers if any.
+func sendAs(router *gin.Engine, userID uint, method string, url string, body any, headers ...map[string]string) *httptest.ResponseRecorder {
+	var content bytes.Buffer
+
+	if body != nil {
+		if err := json.NewEncoder(&content).Encode(body); err != nil {
+			log.Fatal("Unable to marshal data: ", err)
+		}
+	}
+
+	request, err := http.NewRequest(method, url, &content)
+	if err != nil {
+		log.Fatal("Unable to create request: ", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	if userID != 0 {
+		request.Header.Set("Authorization", "Bearer "+generateTestToken(userID))
+	}
+
+	for _, values := range headers {
+		for name, value := range values {
+			request.Header.Set(name, value)
+		}
+	}
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	return response
+}
+
+func TestPrivateProject(testing *testing.T) {
+	router := InitTest()
+
+	response := sendAs(router, 1, http.MethodPut, "/api/v1/projects/1", map[string]string{"visibility": "private"})
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	for _, userID := range []uint{0, 2} {
+		response = sendAs(router, userID, http.MethodGet, "/api/v1/projects/1", nil)
+		assert.Equal(testing, http.StatusNotFound, response.Code)
+		assert.Equal(testing, problem.ProjectNotFound, decodeProblem(response).Code)
+
+		response = sendAs(router, userID, http.MethodGet, "/api/v1/projects/", nil)
+		assert.NotContains(testing, response.Body.String(), "Test project 1")
+	}
+
+	response = sendAs(router, 2, http.MethodPost, "/api/v1/comments/", map[string]any{"project_id": 1, "content": "Hidden"})
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	response = sendAs(router, 2, http.MethodPut, "/api/v1/projects/1/like", nil)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	response = sendAs(router, 1, http.MethodGet, "/api/v1/projects/", nil)
+	assert.Contains(testing, response.Body.String(), "Test project 1")
+}
+
+func TestProjectCollaborators(testing *testing.T) {
+	router := InitTest()
+
+	sendAs(router, 1, http.MethodPut, "/api/v1/projects/1", map[string]string{"visibility": "private"})
+
+	response := sendAs(router, 2, http.MethodPut, "/api/v1/projects/1/collaborators/2", nil)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+
+	response = sendAs(router, 1, http.MethodPut, "/api/v1/projects/1/collaborators/42", nil)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+	assert.Equal(testing, problem.UserNotFound, decodeProblem(response).Code)
+
+	response = sendAs(router, 1, http.MethodPut, "/api/v1/projects/1/collaborators/2", nil)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Contains(testing, response.Body.String(), `"collaborator_ids":[2]`)
+
+	response = sendAs(router, 2, http.MethodGet, "/api/v1/projects/", nil)
+	assert.Contains(testing, response.Body.String(), "Test project 1")
+
+	response = sendAs(router, 2, http.MethodPut, "/api/v1/projects/1", map[string]string{"name": "Renamed by collaborator"})
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = sendAs(router, 2, http.MethodPut, "/api/v1/projects/1", map[string]string{"visibility": "public"})
+	assert.Equal(testing, http.StatusForbidden, response.Code)
+
+	response = sendAs(router, 1, http.MethodDelete, "/api/v1/projects/1/collaborators/2", nil)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = sendAs(router, 2, http.MethodGet, "/api/v1/projects/1", nil)
+	assert.Equal(testing, http.StatusNotFound, response.Code)
+}
+
+func TestNonMemberCannotChangeProject(testing *testing.T) {
+	router, directory := InitTestWithUploads(testing)
+
+	images := createTestProjectImages(directory)
+	url := "/api/v1/projects/1"
+
+	// The project is public: user 2 can see it, but isn't one of its members.
+	changes := []struct {
+		method string
+		url    string
+		body   any
+	}{
+		{http.MethodPut, url, map[string]string{"name": "Renamed by a stranger"}},
+		{http.MethodDelete, url, nil},
+		{http.MethodPut, url + "/images/order", map[string]any{"image_ids": []uint{images[2].ID, images[1].ID, images[0].ID}}},
+		{http.MethodPut, url + "/images/" + formatID(images[1].ID) + "/cover", nil},
+		{http.MethodDelete, url + "/images/" + formatID(images[0].ID), nil},
+	}
+
+	for _, change := range changes {
+		response := sendAs(router, 2, change.method, change.url, change.body)
+
+		assert.Equal(testing, http.StatusForbidden, response.Code, change.method+" "+change.url)
+		assert.Equal(testing, problem.Forbidden, decodeProblem(response).Code)
+	}
+
+	request := CreateMultipartRequest(http.MethodPost, url+"/images", nil, true)
+	request.Header.Set("Authorization", "Bearer "+generateTestToken(2))
+
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+
+	assert.Equal(testing, http.StatusForbidden, response.Code)
+
+	project := decodeProject(sendAs(router, 1, http.MethodGet, url, nil))
+
+	assert.Equal(testing, "Test project 1", project.Name)
+	assert.Len(testing, project.Images, len(images))
+	assert.True(testing, project.Images[0].Cover)
+
+	// A collaborator can change the project, but only its owner can delete it.
+	sendAs(router, 1, http.MethodPut, url+"/collaborators/2", nil)
+
+	assert.Equal(testing, http.StatusOK, sendAs(router, 2, http.MethodPut, url, map[string]string{"name": "Renamed by a collaborator"}).Code)
+	assert.Equal(testing, http.StatusForbidden, sendAs(router, 2, http.MethodDelete, url, nil).Code)
+	assert.Equal(testing, http.StatusOK, sendAs(router, 1, http.MethodDelete, url, nil).Code)
+}
+
+func TestUnlistedProject(testing *testing.T) {
+	router := InitTest()
+
+	response := sendAs(router, 1, http.MethodPost, "/api/v1/projects/", map[string]string{"name": "Unlisted project", "description": "Unlisted", "visibility": "unlisted"})
+	assert.Equal(testing, http.StatusCreated, response.Code)
+
+	var project struct {
+		ID      uint
+		OwnerID uint `json:"owner_id"`
+	}
+
+	if err := json.Unmarshal(response.Body.Bytes(), &project); err != nil {
+		log.Fatal("Unable to unmarshal response: ", err)
+	}
+
+	assert.Equal(testing, uint(1), project.OwnerID)
+
+	response = sendAs(router, 1, http.MethodPut, "/api/v1/projects/"+formatID(project.ID)+"/publish", nil)
+	assert.Equal(testing, http.StatusOK, response.Code)
+
+	response = sendAs(router, 0, http.MethodGet, "/api/v1/projects/", nil)
+	assert.NotContains(testing, response.Body.String(), "Unlisted project")
+
+	response = sendAs(router, 0, http.MethodGet, "/api/v1/projects/"+formatID(project.ID), nil)
+	assert.Equal(testing, http.StatusOK, response.Code)
+	assert.Contains(testing, response.Body.String(), "Unlisted project")
+}
+
+func TestInvalidVisibility(testing *testing.T) {
+	router := InitTest()
+
+	response := sendAs(router, 1, http.MethodPut, "/api/v1/projects/1", map[string]string{"visibility": "secret"})
+
+	assert.Equal(testing, http.StatusBadRequest, response.Code)
+	assert.Equal(testing, []problem.FieldError{
+		{Field: "visibility", Code: problem.FieldOneOf, Detail: "This field must be one of: public, unlisted, private."},
+	}, decodeProblem(response).Errors)
+}
@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"gorm.io/gorm"
+	otelgorm "gorm.io/plugin/opentelemetry/tracing"
+)
+
+const ServiceName = "partage-projets"
+
+// Setup installs the global tracer provider and the W3C trace context propagator. With the "none" exporter,
+// spans are still created, so that trace IDs appear in logs and are propagated, but they are not exported.
+func Setup(ctx context.Context, exporter string, endpoint string, sampleRatio float64) (func(context.Context) error, error) {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(ServiceName))),
+	}
+
+	if exporter == "otlp" {
+		// Without an endpoint, the exporter falls back to the standard OTEL_EXPORTER_OTLP_* variables.
+		var exporterOptions []otlptracehttp.Option
+		if endpoint != "" {
+			exporterOptions = append(exporterOptions, otlptracehttp.WithEndpointURL(endpoint))
+		}
+
+		spanExporter, err := otlptracehttp.New(ctx, exporterOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create trace exporter: %w", err)
+		}
+
+		options = append(options, sdktrace.WithBatcher(spanExporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(options...)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+// NewGormPlugin creates a span for each query. Query variables are left out, as they may hold personal data.
+func NewGormPlugin() gorm.Plugin {
+	return otelgorm.NewPlugin(otelgorm.WithoutMetrics(), otelgorm.WithoutQueryVariables())
+}
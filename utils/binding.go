@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"encoding/json"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// BindData binds a plain JSON body, or a multipart form carrying either a JSON "data" part or regular form fields.
+func BindData(context *gin.Context, object any) error {
+	if context.ContentType() != binding.MIMEMultipartPOSTForm {
+		return context.ShouldBindJSON(object)
+	}
+
+	if data := context.PostForm("data"); data != "" {
+		if err := json.Unmarshal([]byte(data), object); err != nil {
+			return err
+		}
+
+		return binding.Validator.ValidateStruct(object)
+	}
+
+	return context.ShouldBindWith(object, binding.FormMultipart)
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var contentAddressedName = regexp.MustCompile("^[0-9a-f]{64}$")
+
+func IsContentAddressed(name string) bool {
+	base := filepath.Base(name)
+
+	return contentAddressedName.MatchString(strings.TrimSuffix(base, filepath.Ext(base)))
+}
+
+func MediaETag(name string, content io.Reader) (string, error) {
+	if IsContentAddressed(name) {
+		base := filepath.Base(name)
+
+		return `"` + strings.TrimSuffix(base, filepath.Ext(base)) + `"`, nil
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+
+	return `"` + hex.EncodeToString(hash.Sum(nil)) + `"`, nil
+}
@@ -1,13 +1,13 @@
 package utils
 
 import (
-	"errors"
+	"partage-projets/problem"
 	"regexp"
 )
 
 func ValidatePassword(password string) error {
 	if len(password) < 8 {
-		return errors.New("password must be at least 6 characters long")
+		return problem.PasswordTooShort
 	}
 
 	var (
@@ -18,19 +18,19 @@ func ValidatePassword(password string) error {
 	)
 
 	if !upper.MatchString(password) {
-		return errors.New("password must contain at least one uppercase letter")
+		return problem.PasswordMissingUpper
 	}
 
 	if !lower.MatchString(password) {
-		return errors.New("password must contain at least one lowercase letter")
+		return problem.PasswordMissingLower
 	}
 
 	if !number.MatchString(password) {
-		return errors.New("password must contain at least one number")
+		return problem.PasswordMissingNumber
 	}
 
 	if !special.MatchString(password) {
-		return errors.New("password must contain at least one special character")
+		return problem.PasswordMissingSpecial
 	}
 
 	return nil